@@ -2,12 +2,11 @@ package numgo
 
 import (
 	"fmt"
-	"math"
 	"runtime"
 )
 
 // Flatten reshapes the data to a 1-D array.
-func (a *Array64) Flatten() *Array64 {
+func (a *Array[T]) Flatten() *Array[T] {
 	if a.HasErr() {
 		return a
 	}
@@ -15,28 +14,29 @@ func (a *Array64) Flatten() *Array64 {
 }
 
 // C will return a deep copy of the source array.
-func (a *Array64) C() (b *Array64) {
+func (a *Array[T]) C() (b *Array[T]) {
 	if a.HasErr() {
 		return a
 	}
 
-	b = &Array64{
+	b = &Array[T]{
 		shape:   make([]int, len(a.shape)),
 		strides: make([]int, len(a.strides)),
-		data:    make([]float64, a.strides[0]),
+		data:    make([]T, a.strides[0]),
 		err:     nil,
 		debug:   "",
 		stack:   "",
 	}
 
 	copy(b.shape, a.shape)
-	copy(b.strides, a.strides)
-	copy(b.data, a.data)
+	b.strides[0] = a.strides[0]
+	copy(b.strides[1:], rowStrides(a.shape))
+	gather(b.data, a.data, a.offset, a.shape, a.strides[1:])
 	return b
 }
 
 // Shape returns a copy of the array shape
-func (a *Array64) Shape() []int {
+func (a *Array[T]) Shape() []int {
 	if a.HasErr() {
 		return nil
 	}
@@ -46,26 +46,37 @@ func (a *Array64) Shape() []int {
 	return res
 }
 
+// Values returns a copy of the array elements in row-major order.
+func (a *Array[T]) Values() []T {
+	if a.HasErr() {
+		return nil
+	}
+
+	ret := make([]T, a.strides[0])
+	gather(ret, a.data, a.offset, a.shape, a.strides[1:])
+	return ret
+}
+
 // At returns the element at the given index.
 // There should be one index per axis.  Generates a ShapeError if incorrect index.
-func (a *Array64) At(index ...int) float64 {
+func (a *Array[T]) At(index ...int) T {
 	idx := a.valIdx(index, "At")
 	if a.HasErr() {
-		return math.NaN()
+		return kern[T]().nan
 	}
 
 	return a.data[idx]
 }
 
-func (a *Array64) at(index []int) float64 {
-	var idx int
+func (a *Array[T]) at(index []int) T {
+	idx := a.offset
 	for i, v := range index {
 		idx += v * a.strides[i+1]
 	}
 	return a.data[idx]
 }
 
-func (a *Array64) valIdx(index []int, mthd string) (idx int) {
+func (a *Array[T]) valIdx(index []int, mthd string) (idx int) {
 	if a.HasErr() {
 		return 0
 	}
@@ -77,6 +88,7 @@ func (a *Array64) valIdx(index []int, mthd string) (idx int) {
 		}
 		return 0
 	}
+	idx = a.offset
 	for i, v := range index {
 		if v >= a.shape[i] || v < 0 {
 			a.err = IndexError
@@ -93,7 +105,7 @@ func (a *Array64) valIdx(index []int, mthd string) (idx int) {
 
 // SliceElement returns the element group at one axis above the leaf elements.
 // Data is returned as a copy  in a float slice.
-func (a *Array64) SliceElement(index ...int) (ret []float64) {
+func (a *Array[T]) SliceElement(index ...int) (ret []T) {
 	idx := a.valIdx(index, "SliceElement")
 	switch {
 	case a.HasErr():
@@ -107,25 +119,30 @@ func (a *Array64) SliceElement(index ...int) (ret []float64) {
 		return nil
 	}
 
-	return append(ret, a.data[idx:idx+a.strides[len(a.strides)-2]]...)
+	ret = make([]T, a.shape[len(a.shape)-1])
+	gather(ret, a.data, idx, a.shape[len(index):], a.strides[len(a.strides)-1:])
+	return ret
 }
 
 // SubArr slices the array at a given index.
-func (a *Array64) SubArr(index ...int) (ret *Array64) {
+//
+// The returned array is a view that shares data with the source array,
+// so changes to either array will be seen in both.  Use C() to make an independent copy.
+func (a *Array[T]) SubArr(index ...int) (ret *Array[T]) {
 	idx := a.valIdx(index, "SubArr")
 	if a.HasErr() {
 		return a
 	}
 
-	ret = newArray64(a.shape[len(index):]...)
-	copy(ret.data, a.data[idx:idx+a.strides[len(index)]])
-
-	return
+	sh, st := make([]int, len(a.shape)-len(index)), make([]int, len(a.shape)-len(index))
+	copy(sh, a.shape[len(index):])
+	copy(st, a.strides[len(index)+1:])
+	return a.view(idx, sh, st)
 }
 
 // Set sets the element at the given index.
 // There should be one index per axis.  Generates a ShapeError if incorrect index.
-func (a *Array64) Set(val float64, index ...int) *Array64 {
+func (a *Array[T]) Set(val T, index ...int) *Array[T] {
 	idx := a.valIdx(index, "Set")
 	if a.HasErr() {
 		return a
@@ -137,7 +154,7 @@ func (a *Array64) Set(val float64, index ...int) *Array64 {
 
 // SetSliceElement sets the element group at one axis above the leaf elements.
 // Source Array is returned, for function-chaining design.
-func (a *Array64) SetSliceElement(vals []float64, index ...int) *Array64 {
+func (a *Array[T]) SetSliceElement(vals []T, index ...int) *Array[T] {
 	idx := a.valIdx(index, "SetSliceElement")
 	switch {
 	case a.HasErr():
@@ -157,13 +174,13 @@ func (a *Array64) SetSliceElement(vals []float64, index ...int) *Array64 {
 		return a
 	}
 
-	copy(a.data[idx:idx+a.strides[len(a.strides)-2]], vals[:a.strides[len(a.strides)-2]])
+	scatter(a.data, vals, idx, a.shape[len(a.shape)-1:], a.strides[len(a.strides)-1:])
 	return a
 }
 
 // SetSubArr sets the array below a given index to the values in vals.
 // Values will be broadcast up multiple axes if the shapes match.
-func (a *Array64) SetSubArr(vals *Array64, index ...int) *Array64 {
+func (a *Array[T]) SetSubArr(vals *Array[T], index ...int) *Array[T] {
 	idx := a.valIdx(index, "SetSubArr")
 	switch {
 	case a.HasErr():
@@ -195,6 +212,14 @@ func (a *Array64) SetSubArr(vals *Array64, index ...int) *Array64 {
 		}
 	}
 
+	if !a.contig() || !vals.contig() {
+		sh := a.shape[len(index):]
+		d := make([]T, size(sh))
+		gather(d, vals.data, vals.offset, sh, broadcastStrides(sh, vals.shape, vals.strides[1:]))
+		scatter(a.data, d, idx, sh, a.strides[len(index)+1:])
+		return a
+	}
+
 	if len(a.shape)-len(index)-len(vals.shape) == 0 {
 		copy(a.data[idx:idx+len(vals.data)], vals.data)
 		return a
@@ -216,14 +241,14 @@ func (a *Array64) SetSubArr(vals *Array64, index ...int) *Array64 {
 //
 // Make a copy C() if the original array needs to remain unchanged.
 // Element location in the underlying slice will not be adjusted to the new shape.
-func (a *Array64) Resize(shape ...int) *Array64 {
+func (a *Array[T]) Resize(shape ...int) *Array[T] {
 	switch {
 	case a.HasErr():
 		return a
 	case len(shape) == 0:
-		tmp := newArray64(0)
+		tmp := newArray[T](0)
 		a.shape, a.strides = tmp.shape, tmp.strides
-		a.data = tmp.data
+		a.data, a.offset, a.shared = tmp.data, 0, false
 		return a
 	}
 
@@ -242,6 +267,7 @@ func (a *Array64) Resize(shape ...int) *Array64 {
 		return a
 	}
 
+	a.own()
 	ln, cp := len(shape), cap(a.shape)
 	if ln > cp {
 		a.shape = append(a.shape[:cp], make([]int, ln-cp)...)
@@ -264,7 +290,7 @@ func (a *Array64) Resize(shape ...int) *Array64 {
 
 	cp = cap(a.data)
 	if sz > cp {
-		a.data = append(a.data[:cp], make([]float64, sz-cp)...)
+		a.data = append(a.data[:cp], make([]T, sz-cp)...)
 	} else {
 		a.data = a.data[:sz]
 	}
@@ -276,7 +302,7 @@ func (a *Array64) Resize(shape ...int) *Array64 {
 //
 // Source array will be changed, so use C() if the original data is needed.
 // All axes must be the same except the appending axis.
-func (a *Array64) Append(val *Array64, axis int) *Array64 {
+func (a *Array[T]) Append(val *Array[T], axis int) *Array[T] {
 	switch {
 	case a.HasErr():
 		return a
@@ -314,18 +340,20 @@ func (a *Array64) Append(val *Array64, axis int) *Array64 {
 		}
 	}
 
-	ln := len(a.data) + len(val.data)
-	var dat []float64
+	a.own()
+	vd := val.flat()
+	ln := len(a.data) + len(vd)
+	var dat []T
 	cp := cap(a.data)
 	if ln > cp {
-		dat = make([]float64, ln)
+		dat = make([]T, ln)
 	} else {
 		dat = a.data[:ln]
 	}
 
-	as, vs := a.strides[axis], val.strides[axis]
-	for i, j := a.strides[0], val.strides[0]; i > 0; i, j = i-as, j-vs {
-		copy(dat[i+j-vs:i+j], val.data[j-vs:j])
+	as, vs := a.strides[axis], size(val.shape[axis:])
+	for i, j := a.strides[0], len(vd); i > 0; i, j = i-as, j-vs {
+		copy(dat[i+j-vs:i+j], vd[j-vs:j])
 		copy(dat[i+j-as-vs:i+j-vs], a.data[i-as:i])
 	}
 
@@ -338,3 +366,17 @@ func (a *Array64) Append(val *Array64, axis int) *Array64 {
 
 	return a
 }
+
+// permute returns a contiguous copy of the array with the axes reordered,
+// so axis i of the result is axis perm[i] of the source.
+// Validation must be complete before calling permute.
+func (a *Array[T]) permute(perm []int) *Array[T] {
+	sh, st := make([]int, len(perm)), make([]int, len(perm))
+	for i, v := range perm {
+		sh[i], st[i] = a.shape[v], a.strides[v+1]
+	}
+
+	r := newArray[T](sh...)
+	gather(r.data, a.data, a.offset, sh, st)
+	return r
+}
@@ -85,6 +85,39 @@ func TestShape(t *testing.T) {
 	}
 }
 
+func TestValues(t *testing.T) {
+	a := Arange(6).Reshape(2, 3)
+	for i, v := range []struct {
+		a   *Array64
+		res []float64
+	}{
+		{a, []float64{0, 1, 2, 3, 4, 5}},
+		{a.T(), []float64{0, 3, 1, 4, 2, 5}},
+		{a.SubArr(1), []float64{3, 4, 5}},
+		{a.C().Reshape(-1), nil},
+	} {
+		r := v.a.Values()
+		if len(r) != len(v.res) || (r == nil) != (v.res == nil) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+			continue
+		}
+		for j := range r {
+			if r[j] != v.res[j] {
+				t.Log("Test", i, "Expected", v.res, "Got", r)
+				t.Fail()
+				break
+			}
+		}
+	}
+
+	a.Values()[0] = 10
+	if a.At(0, 0) != 0 {
+		t.Log("Values() shares data with the array")
+		t.Fail()
+	}
+}
+
 func TestAt(t *testing.T) {
 	t.Parallel()
 	a := Arange(125).Reshape(5, 5, 5)
@@ -2,106 +2,75 @@ package numgo
 
 import (
 	"fmt"
-	"math"
 	"runtime"
-	"sync"
-
-	"github.com/Kunde21/numgo/internal"
 )
 
-var nan float64
-
-func init() {
-	nan = math.NaN()
-}
-
 // Add performs element-wise addition
 // Arrays must be the same size or able to broadcast.
 // This will modify the source array.
-func (a *Array64) Add(b *Array64) *Array64 {
+func (a *Array[T]) Add(b *Array[T]) *Array[T] {
 	if a.valRith(b, "Add") {
 		return a
 	}
 
-	if b.shape[len(b.shape)-1] == a.shape[len(a.shape)-1] {
-		asm.Add(a.data, b.data)
-		return a
-	}
-
-	st := a.strides[len(a.strides)-1] * a.shape[len(a.shape)-1]
-	for i := 0; i < len(b.data); i++ {
-		asm.AddC(b.data[i], a.data[i*st:(i+1)*st])
-	}
-	return a
+	k := kern[T]()
+	return a.rith(b, k.add, k.addC)
 }
 
 // AddC adds a constant to all elements of the array.
-func (a *Array64) AddC(b float64) *Array64 {
-	if a.HasErr() {
+func (a *Array[T]) AddC(b T) *Array[T] {
+	k := kern[T]()
+	if a.HasErr() || a.valOp(k.addC != nil, "AddC") {
 		return a
 	}
 
-	asm.AddC(b, a.data)
+	a.apply(func(d []T) { k.addC(b, d) })
 	return a
 }
 
 // Subtr performs element-wise subtraction.
 // Arrays must be the same size or albe to broadcast.
 // This will modify the source array.
-func (a *Array64) Subtr(b *Array64) *Array64 {
+func (a *Array[T]) Subtr(b *Array[T]) *Array[T] {
 	if a.valRith(b, "Subtr") {
 		return a
 	}
 
-	if b.shape[len(b.shape)-1] == a.shape[len(a.shape)-1] {
-		asm.Subtr(a.data, b.data)
-		return a
-	}
-
-	st := a.strides[len(a.strides)-1] * a.shape[len(a.shape)-1]
-	for i := 0; i < len(b.data); i++ {
-		asm.SubtrC(b.data[i], a.data[i*st:(i+1)*st])
-	}
-	return a
+	k := kern[T]()
+	return a.rith(b, k.subtr, k.subtrC)
 }
 
 // SubtrC subtracts a constant from all elements of the array.
-func (a *Array64) SubtrC(b float64) *Array64 {
-	if a.HasErr() {
+func (a *Array[T]) SubtrC(b T) *Array[T] {
+	k := kern[T]()
+	if a.HasErr() || a.valOp(k.subtrC != nil, "SubtrC") {
 		return a
 	}
 
-	asm.SubtrC(b, a.data)
+	a.apply(func(d []T) { k.subtrC(b, d) })
 	return a
 }
 
 // Mult performs element-wise multiplication.
 // Arrays must be the same size or able to broadcast.
 // This will modify the source array.
-func (a *Array64) Mult(b *Array64) *Array64 {
+func (a *Array[T]) Mult(b *Array[T]) *Array[T] {
 	if a.valRith(b, "Mult") {
 		return a
 	}
 
-	if b.shape[len(b.shape)-1] == a.shape[len(a.shape)-1] {
-		asm.Mult(a.data, b.data)
-		return a
-	}
-
-	st := a.strides[len(a.strides)-1] * a.shape[len(a.shape)-1]
-	for i := 0; i < len(b.data); i++ {
-		asm.MultC(b.data[i], a.data[i*st:(i+1)*st])
-	}
-	return a
+	k := kern[T]()
+	return a.rith(b, k.mult, k.multC)
 }
 
 // MultC multiplies all elements of the array by a constant.
-func (a *Array64) MultC(b float64) *Array64 {
-	if a.HasErr() {
+func (a *Array[T]) MultC(b T) *Array[T] {
+	k := kern[T]()
+	if a.HasErr() || a.valOp(k.multC != nil, "MultC") {
 		return a
 	}
 
-	asm.MultC(b, a.data)
+	a.apply(func(d []T) { k.multC(b, d) })
 	return a
 }
 
@@ -109,128 +78,97 @@ func (a *Array64) MultC(b float64) *Array64 {
 // Arrays must be the same size or able to broadcast.
 // Division by zero conforms to IEEE 754
 // 0/0 = NaN, +x/0 = +Inf, -x/0 = -Inf
+// Integer division by zero results in zero.
 // This will modify the source array.
-func (a *Array64) Div(b *Array64) *Array64 {
+func (a *Array[T]) Div(b *Array[T]) *Array[T] {
 	if a.valRith(b, "Div") {
 		return a
 	}
 
-	if b.shape[len(b.shape)-1] == a.shape[len(a.shape)-1] {
-		asm.Div(a.data, b.data)
-		return a
-	}
-
-	st := a.strides[len(a.strides)-1] * a.shape[len(a.shape)-1]
-	for i := 0; i < len(b.data); i++ {
-		asm.DivC(b.data[i], a.data[i*st:(i+1)*st])
-	}
-	return a
+	k := kern[T]()
+	return a.rith(b, k.div, k.divC)
 }
 
 // DivC divides all elements of the array by a constant.
 // Division by zero conforms to IEEE 754
 // 0/0 = NaN, +x/0 = +Inf, -x/0 = -Inf
-func (a *Array64) DivC(b float64) *Array64 {
+// Integer division by zero results in zero.
+func (a *Array[T]) DivC(b T) *Array[T] {
+	k := kern[T]()
 	switch {
-	case a.HasErr():
+	case a.HasErr(), a.valOp(k.divC != nil, "DivC"):
 		return a
 	}
 
-	asm.DivC(b, a.data)
+	a.apply(func(d []T) { k.divC(b, d) })
 	return a
 }
 
 // Pow raises elements of a to the corresponding power in b.
 // Arrays must be the same size or able to broadcast.
 // This will modify the source array.
-func (a *Array64) Pow(b *Array64) *Array64 {
+func (a *Array[T]) Pow(b *Array[T]) *Array[T] {
 	if a.valRith(b, "Pow") {
 		return a
 	}
 
-	if b.shape[len(b.shape)-1] == a.shape[len(a.shape)-1] {
-		lna, lnb := len(a.data), len(b.data)
-		for i, j := 0, 0; i < lna; i, j = i+1, j+1 {
-			if j >= lnb {
-				j = 0
-			}
-			a.data[i] = math.Pow(a.data[i], b.data[j])
-		}
-		return a
-	}
-
-	st := a.strides[len(a.strides)-1] * a.shape[len(a.shape)-1]
-	for i := 0; i < len(b.data); i++ {
-		for j := i * st; j < (i+1)*st; j++ {
-			a.data[j] = math.Pow(a.data[j], b.data[i])
-		}
-	}
-	return a
+	k := kern[T]()
+	return a.rith(b, k.pow, k.powC)
 }
 
 // PowC raises all elements to a constant power.
 // Negative powers will result in a math.NaN() values.
-func (a *Array64) PowC(b float64) *Array64 {
-	if a.HasErr() {
+// Integer arrays truncate negative powers towards zero.
+func (a *Array[T]) PowC(b T) *Array[T] {
+	k := kern[T]()
+	if a.HasErr() || a.valOp(k.powC != nil, "PowC") {
 		return a
 	}
 
-	for i := 0; i < len(a.data); i++ {
-		a.data[i] = math.Pow(a.data[i], b)
-	}
+	a.apply(func(d []T) { k.powC(b, d) })
 	return a
 }
 
 // FMA12 is the fuse multiply add functionality.
 // Array x will contain a[i] = x*a[i]+b[i]
-func (a *Array64) FMA12(x float64, b *Array64) *Array64 {
+func (a *Array[T]) FMA12(x T, b *Array[T]) *Array[T] {
 	if a.valRith(b, "FMA") {
 		return a
 	}
 
-	if b.strides[0] != a.strides[0] {
-		cmp, mul := new(sync.WaitGroup), len(a.data)/len(b.data)
-		cmp.Add(mul)
-		for k := 0; k < mul; k++ {
-			go func(m int) {
-				asm.Fma12(x, a.data[m:m+len(b.data)], b.data)
-				cmp.Done()
-			}(k * len(b.data))
-		}
-		cmp.Wait()
-		return a
-	}
-
-	asm.Fma12(x, a.data, b.data)
-	return a
+	k := kern[T]()
+	return a.rith(b, func(d, v []T) {
+		k.fma12(x, d, v)
+	}, func(c T, d []T) {
+		k.multC(x, d)
+		k.addC(c, d)
+	})
 }
 
 // FMA21 is the fuse multiply add functionality.
 // Array x will contain a[i] = a[i]*b[i]+x
-func (a *Array64) FMA21(x float64, b *Array64) *Array64 {
+func (a *Array[T]) FMA21(x T, b *Array[T]) *Array[T] {
 	if a.valRith(b, "FMA") {
 		return a
 	}
-	if b.strides[0] != a.strides[0] {
-		cmp, mul := new(sync.WaitGroup), len(a.data)/len(b.data)
-		cmp.Add(mul)
-		for k := 0; k < mul; k++ {
-			go func(m int) {
-				asm.Fma21(x, a.data[m:m+len(b.data)], b.data)
-				cmp.Done()
-			}(k * len(b.data))
-		}
-		cmp.Wait()
-		return a
-	}
 
-	asm.Fma21(x, a.data, b.data)
-	return a
+	k := kern[T]()
+	return a.rith(b, func(d, v []T) {
+		k.fma21(x, d, v)
+	}, func(c T, d []T) {
+		k.multC(c, d)
+		k.addC(x, d)
+	})
+}
+
+// valRith validates the arguments of element-wise arithmetic.
+// Shapes must be able to broadcast together, and valRith needs to be called before rith.
+func (a *Array[T]) valRith(b *Array[T], mthd string) bool {
+	return a.HasErr() || a.valOp(kern[T]().add != nil, mthd) || a.valBroadcast(b, mthd)
 }
 
-// valAr needs to be called before
-func (a *Array64) valRith(b *Array64, mthd string) bool {
-	var flag bool
+// valBroadcast checks that b is valid and can be broadcast with a.
+func (a *Array[T]) valBroadcast(b *Array[T], mthd string) bool {
 	switch {
 	case a.HasErr():
 		return true
@@ -248,34 +186,208 @@ func (a *Array64) valRith(b *Array64, mthd string) bool {
 			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
 		}
 		return true
-	case len(a.shape) < len(b.shape):
-		goto shape
 	}
 
-	for i, j := len(b.shape)-1, len(a.shape)-1; i >= 0; i, j = i-1, j-1 {
-		if a.shape[j] != b.shape[i] {
-			flag = true
+	if _, ok := broadcastShape(a.shape, b.shape); !ok {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Array received by %s() can not be broadcast.  Shape: %v  Val shape: %v",
+				mthd, a.shape, b.shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return true
+	}
+	return false
+}
+
+// rith applies an element-wise operation between a and b, broadcasting both arrays to the result shape.
+// The receiver is expanded in place when the result is larger than a.
+//
+// vec applies the operation between slices, repeating v when it's shorter than d.
+// sc applies the operation between d and a single value of b.
+func (a *Array[T]) rith(b *Array[T], vec func(d, v []T), sc func(c T, d []T)) *Array[T] {
+	sh, _ := broadcastShape(a.shape, b.shape)
+	if len(sh) != len(a.shape) || size(sh) != a.strides[0] {
+		a.expand(sh)
+	}
+	if a.strides[0] == 0 {
+		return a
+	}
+
+	// b may be a view of the same data, so it's copied to avoid reading modified values.
+	bd := b.flat()
+	if a.shared && b.shared && b.contig() {
+		bd = append([]T(nil), bd...)
+	}
+
+	// Element strides of b in the result shape.  Broadcast axes don't move.
+	bst := broadcastStrides(sh, b.shape, rowStrides(b.shape))
+
+	// Find the largest block of trailing axes that is either fully
+	// broadcast (scalar block) or fully present in b (vector block).
+	k, scalar, set := len(sh), false, false
+	for ; k > 0; k-- {
+		if sh[k-1] == 1 {
+			continue
+		}
+		if !set {
+			scalar, set = bst[k-1] == 0, true
+		}
+		if scalar != (bst[k-1] == 0) {
 			break
 		}
 	}
-	if !flag {
-		return false
+
+	ln := size(sh[k:])
+	outer := !scalar
+	for _, v := range bst[:k] {
+		outer = outer && v == 0
+	}
+
+	a.apply(func(ad []T) {
+		if outer {
+			vec(ad, bd[:ln])
+			return
+		}
+
+		idx := make([]int, k)
+		for i, off := 0, 0; i < len(ad); i += ln {
+			if scalar {
+				sc(bd[off], ad[i:i+ln])
+			} else {
+				vec(ad[i:i+ln], bd[off:off+ln])
+			}
+
+			for j := k - 1; j >= 0; j-- {
+				idx[j]++
+				off += bst[j]
+				if idx[j] < sh[j] {
+					break
+				}
+				off -= bst[j] * idx[j]
+				idx[j] = 0
+			}
+		}
+	})
+	return a
+}
+
+// expand replaces the data of a with its values broadcast to shape sh.
+// The shapes must be compatible before calling expand.
+func (a *Array[T]) expand(sh []int) {
+	st := broadcastStrides(sh, a.shape, a.strides[1:])
+	r := newArray[T](sh...)
+	gather(r.data, a.data, a.offset, sh, st)
+	a.shape, a.strides, a.data = r.shape, r.strides, r.data
+	a.offset, a.shared = 0, false
+}
+
+// broadcastStrides calculates the element strides of an array with shape and strides st
+// when broadcast up to shape sh.  Broadcast axes have a stride of zero.
+func broadcastStrides(sh, shape, st []int) []int {
+	bst := make([]int, len(sh))
+	for i, j := len(sh)-1, len(shape)-1; j >= 0; i, j = i-1, j-1 {
+		if shape[j] == sh[i] && sh[i] != 1 {
+			bst[i] = st[j]
+		}
 	}
-	if len(b.shape) != len(a.shape) || b.shape[len(b.shape)-1] != 1 {
-		goto shape
+	return bst
+}
+
+// broadcastShape calculates the shape that a and b broadcast to.
+// Axes are matched from the right, and must be equal or have a length of 1.
+func broadcastShape(a, b []int) ([]int, bool) {
+	if len(a) < len(b) {
+		a, b = b, a
 	}
-	for i := 0; i < len(a.shape)-1; i++ {
-		if a.shape[i] != b.shape[i] {
-			goto shape
+	sh := make([]int, len(a), len(a)+2)
+	copy(sh, a)
+	for i, j := len(a)-1, len(b)-1; j >= 0; i, j = i-1, j-1 {
+		switch {
+		case sh[i] == b[j], b[j] == 1:
+		case sh[i] == 1:
+			sh[i] = b[j]
+		default:
+			return nil, false
 		}
 	}
-	return false
-shape:
-	a.err = ShapeError
-	if debug {
-		a.debug = fmt.Sprintf("Array received by %s() can not be broadcast.  Shape: %v  Val shape: %v",
-			mthd, a.shape, b.shape)
-		a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+	return sh, true
+}
+
+// equalShape checks that two shapes are identical.
+func equalShape(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
 	}
 	return true
 }
+
+// size calculates the number of elements in an array of the given shape.
+func size(shape []int) int {
+	sz := 1
+	for _, v := range shape {
+		sz *= v
+	}
+	return sz
+}
+
+// BroadcastShapes calculates the shape that arrays with the given shapes will broadcast to.
+//
+// Shapes are matched starting from the inner-most axis, and each axis must either be
+// the same length or have a length of 1.  Missing outer axes are treated as length 1.
+// Shapes that can't be broadcast together will return a ShapeError.
+func BroadcastShapes(shapes ...[]int) ([]int, error) {
+	sh := []int{}
+	for _, v := range shapes {
+		for _, w := range v {
+			if w < 0 {
+				return nil, NegativeAxis
+			}
+		}
+
+		var ok bool
+		if sh, ok = broadcastShape(sh, v); !ok {
+			return nil, ShapeError
+		}
+	}
+	return sh[:len(sh):len(sh)], nil
+}
+
+// BroadcastTo expands the array to the given shape, repeating values along broadcast axes.
+// The array shape must be able to broadcast to the new shape without changing it.
+//
+// This will modify the source array.
+func (a *Array[T]) BroadcastTo(shape ...int) *Array[T] {
+	if a.HasErr() {
+		return a
+	}
+
+	for _, v := range shape {
+		if v < 0 {
+			a.err = NegativeAxis
+			if debug {
+				a.debug = fmt.Sprintf("Negative axis length received by BroadcastTo().  Shape: %v", shape)
+				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return a
+		}
+	}
+
+	sh, ok := broadcastShape(a.shape, shape)
+	if !ok || !equalShape(sh, shape) {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Array can not be broadcast by BroadcastTo().  Shape: %v  New shape: %v", a.shape, shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	}
+
+	a.expand(sh)
+	return a
+}
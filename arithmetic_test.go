@@ -427,6 +427,170 @@ func TestValRith(t *testing.T) {
 	}
 }
 
+func TestBroadcastRith(t *testing.T) {
+	t.Parallel()
+	// ref calculates the broadcast result element-by-element.
+	ref := func(a, b *Array64, f func(x, y float64) float64) *Array64 {
+		sh, _ := BroadcastShapes(a.shape, b.shape)
+		r := NewArray64(nil, sh...)
+		idx := make([]int, len(sh))
+		for i := range r.data {
+			for j, k := i, len(sh)-1; k >= 0; j, k = j/sh[k], k-1 {
+				idx[k] = j % sh[k]
+			}
+			ai, bi := make([]int, len(a.shape)), make([]int, len(b.shape))
+			for j := range ai {
+				if a.shape[j] != 1 {
+					ai[j] = idx[len(sh)-len(a.shape)+j]
+				}
+			}
+			for j := range bi {
+				if b.shape[j] != 1 {
+					bi[j] = idx[len(sh)-len(b.shape)+j]
+				}
+			}
+			r.data[i] = f(a.At(ai...), b.At(bi...))
+		}
+		return r
+	}
+
+	shapes := [][2][]int{
+		{{3, 1}, {1, 4}},
+		{{4}, {3, 1}},
+		{{3, 4}, {3, 1}},
+		{{2, 3, 4}, {3, 1}},
+		{{2, 1, 4}, {3, 1}},
+		{{1}, {2, 3}},
+		{{2, 3}, {1}},
+		{{2, 1, 3, 1}, {4, 1, 5}},
+		{{5, 4}, {5, 4}},
+		{{2, 5, 4}, {5, 4}},
+		{{0, 3}, {1, 3}},
+	}
+	ops := []struct {
+		name string
+		op   func(a, b *Array64) *Array64
+		f    func(x, y float64) float64
+	}{
+		{"Add", (*Array64).Add, func(x, y float64) float64 { return x + y }},
+		{"Subtr", (*Array64).Subtr, func(x, y float64) float64 { return x - y }},
+		{"Mult", (*Array64).Mult, func(x, y float64) float64 { return x * y }},
+		{"Div", (*Array64).Div, func(x, y float64) float64 { return x / y }},
+		{"Pow", (*Array64).Pow, math.Pow},
+		{"FMA12", func(a, b *Array64) *Array64 { return a.FMA12(3, b) }, func(x, y float64) float64 { return 3*x + y }},
+		{"FMA21", func(a, b *Array64) *Array64 { return a.FMA21(3, b) }, func(x, y float64) float64 { return x*y + 3 }},
+	}
+
+	seq := func(off float64, sh []int) *Array64 {
+		d := make([]float64, size(sh))
+		for i := range d {
+			d[i] = float64(i) + off
+		}
+		return NewArray64(d, sh...)
+	}
+
+	for i, v := range shapes {
+		a, b := seq(1, v[0]), seq(2, v[1])
+		for _, o := range ops {
+			e := ref(a, b, o.f)
+			c := o.op(a.C(), b)
+			if c.HasErr() {
+				t.Log("Test", i, o.name, "unexpected error", c.GetErr())
+				t.Fail()
+				continue
+			}
+			if len(c.shape) != len(e.shape) {
+				t.Log("Test", i, o.name, "Expected shape", e.shape, "Got", c.shape)
+				t.Fail()
+				continue
+			}
+			if !c.Subtr(e).Map(math.Abs).LessEq(full(1e-9, e.shape...)).All().At(0) {
+				t.Log("Test", i, o.name, "Expected", e, "Got", c)
+				t.Fail()
+			}
+		}
+	}
+
+	for i, v := range [][2][]int{
+		{{3, 2}, {3}},
+		{{2, 3, 4}, {2, 4}},
+		{{0}, {2}},
+	} {
+		if c := NewArray64(nil, v[0]...).Add(NewArray64(nil, v[1]...)); c.GetErr() != ShapeError {
+			t.Log("Shape test", i, "failed to generate ShapeError")
+			t.Fail()
+		}
+	}
+}
+
+func TestBroadcastShapes(t *testing.T) {
+	t.Parallel()
+	for i, v := range []struct {
+		shapes [][]int
+		res    []int
+		err    error
+	}{
+		{[][]int{}, []int{}, nil},
+		{[][]int{{2, 3}}, []int{2, 3}, nil},
+		{[][]int{{3, 1}, {1, 4}}, []int{3, 4}, nil},
+		{[][]int{{4}, {3, 1}}, []int{3, 4}, nil},
+		{[][]int{{5, 1, 3}, {4, 1}, {1}}, []int{5, 4, 3}, nil},
+		{[][]int{{0, 1}, {1, 5}}, []int{0, 5}, nil},
+		{[][]int{{3, 2}, {3}}, nil, ShapeError},
+		{[][]int{{2, 1}, {3, 1}}, nil, ShapeError},
+		{[][]int{{2, -1}}, nil, NegativeAxis},
+	} {
+		sh, err := BroadcastShapes(v.shapes...)
+		if err != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", err)
+			t.Fail()
+			continue
+		}
+		if len(sh) != len(v.res) {
+			t.Log("Test", i, "Expected", v.res, "Got", sh)
+			t.Fail()
+			continue
+		}
+		for j := range sh {
+			if sh[j] != v.res[j] {
+				t.Log("Test", i, "Expected", v.res, "Got", sh)
+				t.Fail()
+				break
+			}
+		}
+	}
+}
+
+func TestBroadcastTo(t *testing.T) {
+	t.Parallel()
+	for i, v := range []struct {
+		a     *Array64
+		shape []int
+		res   *Array64
+		err   error
+	}{
+		{Arange(3), []int{2, 3}, NewArray64([]float64{0, 1, 2, 0, 1, 2}, 2, 3), nil},
+		{Arange(2).Reshape(2, 1), []int{2, 3}, NewArray64([]float64{0, 0, 0, 1, 1, 1}, 2, 3), nil},
+		{Arange(2).Reshape(2, 1), []int{2, 2, 2}, NewArray64([]float64{0, 0, 1, 1, 0, 0, 1, 1}, 2, 2, 2), nil},
+		{Arange(3), []int{3}, Arange(3), nil},
+		{Arange(3), []int{3, 2}, nil, ShapeError},
+		{Arange(6).Reshape(2, 3), []int{3}, nil, ShapeError},
+		{Arange(3), []int{-1, 3}, nil, NegativeAxis},
+		{&Array64{err: InvIndexError}, []int{3}, nil, InvIndexError},
+	} {
+		c := v.a.BroadcastTo(v.shape...)
+		if e := c.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.res != nil && !c.Equals(v.res).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Got", c)
+			t.Fail()
+		}
+	}
+}
+
 func BenchmarkSubtrC(b *testing.B) {
 	a := Arange(500003)
 
@@ -661,3 +825,26 @@ func BenchmarkFMA21_FMAB(b *testing.B) {
 	b.StopTimer()
 	runtime.GC()
 }
+
+func TestVaddTail(t *testing.T) {
+	t.Parallel()
+	// Lengths that aren't a multiple of the unrolled loop run the tail loop,
+	// which must stop at the end of the slice.
+	for n := 0; n <= 19; n++ {
+		a, b := make([]float64, n+1), make([]float64, n+1)
+		for i := range a {
+			a[i], b[i] = float64(i), float64(10*i)
+		}
+		asm.Vadd(a[:n], b[:n])
+		for i, v := range a[:n] {
+			if v != float64(11*i) {
+				t.Log("Length", n, "Index", i, "Expected", 11*i, "Received", v)
+				t.Fail()
+			}
+		}
+		if a[n] != float64(n) {
+			t.Log("Length", n, "wrote past the end of the slice:", a[n])
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package numgo
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Binary encoding.
+//
+// The encoding starts with a header holding the format version, flags, error code, element type
+// and shape, followed by the elements in row-major order.  Elements are stored little-endian,
+// the same as in .npy files, and bit-packed bool elements are stored 8 per byte, lowest bit first.
+//
+//	"NG" version:byte flags:byte err:int8 len(type):byte type ndim:uvarint dims:uvarint... elements
+
+const (
+	binVersion = 1
+	binPacked  = 1 // Flag for bit-packed bool elements.
+)
+
+// MarshalBinary fulfills the encoding.BinaryMarshaler interface for encoding data.
+// Arrays in error are encoded with their error code, and no shape or elements.
+func (a *Array[T]) MarshalBinary() ([]byte, error) {
+	return a.marshalBinary(false), nil
+}
+
+// MarshalBinaryPacked encodes the array the same as MarshalBinary, with bool elements
+// bit-packed to one eighth of the size.  Other element types are encoded unchanged.
+func (a *Array[T]) MarshalBinaryPacked() ([]byte, error) {
+	return a.marshalBinary(true), nil
+}
+
+func (a *Array[T]) marshalBinary(pack bool) []byte {
+	k := kern[T]()
+	code := npyKinds[k.name]
+	typ, _ := parseNpyType("<", code[:1], code[1:])
+	pack = pack && typ.kind == 'b'
+
+	var flags byte
+	if pack {
+		flags |= binPacked
+	}
+	b := append([]byte("NG"), binVersion, flags, byte(encodeErr(a.getErr())), byte(len(code)))
+	b = append(b, code...)
+	if a.HasErr() {
+		return binary.AppendUvarint(b, 0)
+	}
+
+	b = binary.AppendUvarint(b, uint64(len(a.shape)))
+	for _, v := range a.shape {
+		b = binary.AppendUvarint(b, uint64(v))
+	}
+
+	d := a.flat()
+	switch f := any(d).(type) {
+	case []float64:
+		for _, v := range f {
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+		}
+		return b
+	case []bool:
+		if pack {
+			p := make([]byte, (len(f)+7)/8)
+			for i, v := range f {
+				if v {
+					p[i/8] |= 1 << (i % 8)
+				}
+			}
+			return append(b, p...)
+		}
+	}
+
+	n := len(b)
+	b = append(b, make([]byte, len(d)*typ.size)...)
+	for i, v := range d {
+		typ.put(b[n+i*typ.size:], k.load(v))
+	}
+	return b
+}
+
+// UnmarshalBinary fulfills the encoding.BinaryUnmarshaler interface for decoding data.
+// Elements stored with a different element type are converted to T the same way as AsType without checking.
+//
+// Data that can't be decoded generates a FormatError, which is returned and held by the array.
+func (a *Array[T]) UnmarshalBinary(b []byte) error {
+	a.shape, a.strides, a.data, a.offset, a.shared = nil, nil, nil, 0, false
+	a.err, a.debug, a.stack = nil, "", ""
+
+	fail := func(format string, args ...interface{}) error {
+		*a = *npyErr[T]("UnmarshalBinary", format, args...)
+		return FormatError
+	}
+	if len(b) < 6 || string(b[:2]) != "NG" {
+		return fail("Not a numgo encoding.")
+	}
+	if b[2] != binVersion {
+		return fail("Unsupported version: %d", b[2])
+	}
+	flags, code, n := b[3], int8(b[4]), int(b[5])
+	if len(b) < 6+n {
+		return fail("Truncated element type.")
+	}
+	name := string(b[6 : 6+n])
+	typ, ok := npyType{}, n > 1
+	if ok {
+		typ, ok = parseNpyType("<", name[:1], name[1:])
+	}
+	if !ok {
+		return fail("Unsupported element type: %q", name)
+	}
+	b = b[6+n:]
+
+	nd, w := binary.Uvarint(b)
+	if w <= 0 || nd > 64 {
+		return fail("Invalid number of axes.")
+	}
+	b = b[w:]
+	sh, sz := make([]int, nd), 1
+	for i := range sh {
+		v, w := binary.Uvarint(b)
+		if w <= 0 || v > 0 && uint64(sz) > math.MaxInt32/v {
+			return fail("Invalid shape.")
+		}
+		sh[i], sz, b = int(v), sz*int(v), b[w:]
+	}
+
+	if a.err = decodeErr(code); a.err != nil {
+		return nil
+	}
+	if nd == 0 {
+		return fail("Missing shape.")
+	}
+
+	pack := flags&binPacked != 0 && typ.kind == 'b'
+	ln := sz * typ.size
+	if pack {
+		ln = (sz + 7) / 8
+	}
+	if len(b) != ln {
+		return fail("Shape %v needs %d bytes of elements.  Received %d.", sh, ln, len(b))
+	}
+
+	k, r := kern[T](), newArray[T](sh...)
+	if d, ok := any(r.data).([]float64); ok && typ.kind == 'f' && typ.size == 8 {
+		for i := range d {
+			d[i] = math.Float64frombits(binary.LittleEndian.Uint64(b[i*8:]))
+		}
+		*a = *r
+		return nil
+	}
+	for i := range r.data {
+		if pack {
+			r.data[i], _ = k.store(scalar{kind: 'i', i: int64(b[i/8] >> (i % 8) & 1)}, RoundTrunc)
+			continue
+		}
+		r.data[i], _ = k.store(typ.get(b[i*typ.size:]), RoundTrunc)
+	}
+	*a = *r
+	return nil
+}
+
+// GobEncode fulfills the gob.GobEncoder interface, with the same encoding as MarshalBinary.
+func (a *Array[T]) GobEncode() ([]byte, error) {
+	return a.MarshalBinary()
+}
+
+// GobDecode fulfills the gob.GobDecoder interface, with the same decoding as UnmarshalBinary.
+func (a *Array[T]) GobDecode(b []byte) error {
+	return a.UnmarshalBinary(b)
+}
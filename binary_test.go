@@ -0,0 +1,156 @@
+package numgo
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"math"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Array64)(nil)
+	_ encoding.BinaryUnmarshaler = (*Arrayb)(nil)
+	_ gob.GobEncoder             = (*Arrayb)(nil)
+	_ gob.GobDecoder             = (*Array64)(nil)
+)
+
+func TestBinary(t *testing.T) {
+	t.Parallel()
+	nan := math.NaN()
+	for i, v := range []*Array64{
+		Arange(24).Reshape(2, 3, 4),
+		Arange(24).Reshape(2, 3, 4).SubArr(1).T(),
+		NewArray64([]float64{nan, math.Inf(1), math.Inf(-1), -0.5}),
+		NewArray64(nil, 0, 3),
+	} {
+		b, err := v.MarshalBinary()
+		if err != nil {
+			t.Log("Test", i, "Unexpected error", err)
+			t.Fail()
+			continue
+		}
+		r := new(Array64)
+		if err = r.UnmarshalBinary(b); err != nil || !sameNaN(r, v.C()) {
+			t.Log("Test", i, "Expected", v, "Received", r, err)
+			t.Fail()
+		}
+	}
+
+	// The elements are raw little-endian values after the header.
+	if b, _ := NewArray64([]float64{1}).MarshalBinary(); !bytes.Equal(b, []byte{'N', 'G', 1, 0, 0, 2, 'f', '8', 1, 1, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f}) {
+		t.Log("Incorrect encoding", b)
+		t.Fail()
+	}
+
+	c := NewArray([]complex64{1 + 2i, 3, -4i, 0}, 2, 2)
+	b, _ := c.MarshalBinary()
+	if r := new(ArrayC64); r.UnmarshalBinary(b) != nil || !r.Equals(c).All().At(0) {
+		t.Log("Complex round trip failed", r)
+		t.Fail()
+	}
+	if r := new(Array64); r.UnmarshalBinary(b) != nil || !r.Equals(NewArray64([]float64{1, 3, 0, 0}, 2, 2)).All().At(0) {
+		t.Log("Complex to float64 failed", r)
+		t.Fail()
+	}
+	i16 := NewArray([]int16{-7, 300, math.MinInt16}, 3)
+	b, _ = i16.MarshalBinary()
+	if r := new(ArrayI16); r.UnmarshalBinary(b) != nil || !r.Equals(i16).All().At(0) {
+		t.Log("Int round trip failed", r)
+		t.Fail()
+	}
+
+	// Errors are encoded in place of the data.
+	b, _ = Arange(4).Reshape(3).MarshalBinary()
+	if r := new(Array64); r.UnmarshalBinary(b) != nil || r.GetErr() != ReshapeError {
+		t.Log("Error round trip failed", r)
+		t.Fail()
+	}
+	b, _ = (*Array64)(nil).MarshalBinary()
+	if r := new(Array64); r.UnmarshalBinary(b) != nil || r.GetErr() != NilError {
+		t.Log("Nil round trip failed", r)
+		t.Fail()
+	}
+
+	good, _ := Arange(3).MarshalBinary()
+	for i, v := range [][]byte{
+		nil,
+		[]byte("NO\x01\x00\x00\x02f8\x01\x01"),
+		append([]byte{'N', 'G', 2}, good[3:]...),
+		[]byte("NG\x01\x00\x00\x02U4\x01\x01\x00\x00\x00\x00"),
+		[]byte("NG\x01\x00\x00\x08f8"),
+		[]byte("NG\x01\x00\x00\x02f8\x01"),
+		[]byte("NG\x01\x00\x00\x02f8\x00"),
+		[]byte("NG\x01\x00\x00\x02f8\x02\xff\xff\xff\x0f\xff\xff\xff\x0f"),
+		good[:len(good)-1],
+		append(good, 0),
+	} {
+		r := new(Array64)
+		if err := r.UnmarshalBinary(v); err != FormatError || r.GetErr() != FormatError {
+			t.Log("Test", i, "Expected FormatError, received", err)
+			t.Fail()
+		}
+	}
+}
+
+func TestBinaryb(t *testing.T) {
+	t.Parallel()
+	for _, n := range []int{0, 1, 7, 8, 9, 17} {
+		a := newArray[bool](n)
+		for i := range a.data {
+			a.data[i] = i%3 == 0 || i == n-1
+		}
+
+		b, _ := a.MarshalBinary()
+		p, _ := a.MarshalBinaryPacked()
+		if len(b)-len(p) != n-(n+7)/8 {
+			t.Log("Length", n, "Packed size", len(p), "Unpacked size", len(b))
+			t.Fail()
+		}
+		for _, e := range [][]byte{b, p} {
+			r := new(Arrayb)
+			if err := r.UnmarshalBinary(e); err != nil || !equalShape(r.shape, a.shape) || !r.Equals(a).All().At(0) && n > 0 {
+				t.Log("Length", n, "Expected", a, "Received", r, err)
+				t.Fail()
+			}
+		}
+	}
+
+	// Packing only applies to bool elements.
+	a := Arange(5)
+	b, _ := a.MarshalBinary()
+	if p, _ := a.MarshalBinaryPacked(); !bytes.Equal(b, p) {
+		t.Log("Packed float encoding changed", p)
+		t.Fail()
+	}
+	p, _ := NewArrayB([]bool{true, false, true}, 3).MarshalBinaryPacked()
+	if r := new(Array64); r.UnmarshalBinary(p) != nil || !r.Equals(NewArray64([]float64{1, 0, 1})).All().At(0) {
+		t.Log("Packed bool to float64 failed", r)
+		t.Fail()
+	}
+}
+
+func TestGob(t *testing.T) {
+	t.Parallel()
+	type cache struct {
+		Name  string
+		Data  *Array64
+		Mask  *Arrayb
+		Empty *Array64
+	}
+
+	in := cache{"x", Arange(6).Reshape(2, 3), NewArrayB([]bool{true, false}, 2), Arange(2).Reshape(3)}
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(in); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	var out cache
+	if err := gob.NewDecoder(&b).Decode(&out); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	if out.Name != "x" || !out.Data.Equals(Arange(6).Reshape(2, 3)).All().At(0) ||
+		!out.Mask.Equals(NewArrayB([]bool{true, false}, 2)).All().At(0) || out.Empty.GetErr() != ReshapeError {
+		t.Log("Gob round trip failed", out)
+		t.Fail()
+	}
+}
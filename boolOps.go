@@ -2,91 +2,91 @@ package numgo
 
 import (
 	"fmt"
-	"math"
 	"runtime"
 	"sort"
 )
 
 // Equals performs boolean '==' element-wise comparison
-func (a *Array64) Equals(b *Array64) (r *Arrayb) {
+func (a *Array[T]) Equals(b *Array[T]) (r *Arrayb) {
 	r = a.compValid(b, "Equals()")
 	if r != nil {
 		return r
 	}
 
-	r = a.comp(b, func(i, j float64) bool {
-		return i == j || math.IsNaN(i) && math.IsNaN(j)
+	r = a.comp(b, func(i, j T) bool {
+		return i == j || i != i && j != j
 	})
 	return
 }
 
 // NotEq performs boolean '1=' element-wise comparison
-func (a *Array64) NotEq(b *Array64) (r *Arrayb) {
+func (a *Array[T]) NotEq(b *Array[T]) (r *Arrayb) {
 	r = a.compValid(b, "NotEq()")
 	if r != nil {
 		return r
 	}
 
-	r = a.comp(b, func(i, j float64) bool {
-		return i != j && !(math.IsNaN(i) && math.IsNaN(j))
+	r = a.comp(b, func(i, j T) bool {
+		return i != j && !(i != i && j != j)
 	})
 	return
 }
 
 // Less performs boolean '<' element-wise comparison
-func (a *Array64) Less(b *Array64) (r *Arrayb) {
+func (a *Array[T]) Less(b *Array[T]) (r *Arrayb) {
 	r = a.compValid(b, "Less()")
 	if r != nil {
 		return r
 	}
 
-	r = a.comp(b, func(i, j float64) bool {
-		return i < j
-	})
+	r = a.comp(b, kern[T]().lt)
 	return
 }
 
 // LessEq performs boolean '<=' element-wise comparison
-func (a *Array64) LessEq(b *Array64) (r *Arrayb) {
+func (a *Array[T]) LessEq(b *Array[T]) (r *Arrayb) {
 	r = a.compValid(b, "LessEq()")
 	if r != nil {
 		return r
 	}
 
-	r = a.comp(b, func(i, j float64) bool {
-		return i <= j
+	lt := kern[T]().lt
+	r = a.comp(b, func(i, j T) bool {
+		return lt(i, j) || i == j
 	})
 	return
 }
 
 // Greater performs boolean '<' element-wise comparison
-func (a *Array64) Greater(b *Array64) (r *Arrayb) {
+func (a *Array[T]) Greater(b *Array[T]) (r *Arrayb) {
 	r = a.compValid(b, "Greater()")
 	if r != nil {
 		return r
 	}
 
-	r = a.comp(b, func(i, j float64) bool {
-		return i > j
+	lt := kern[T]().lt
+	r = a.comp(b, func(i, j T) bool {
+		return lt(j, i)
 	})
 	return
 }
 
 // GreaterEq performs boolean '<=' element-wise comparison
-func (a *Array64) GreaterEq(b *Array64) (r *Arrayb) {
+func (a *Array[T]) GreaterEq(b *Array[T]) (r *Arrayb) {
 	r = a.compValid(b, "GreaterEq()")
 	if r != nil {
 		return r
 	}
 
-	r = a.comp(b, func(i, j float64) bool {
-		return i >= j
+	lt := kern[T]().lt
+	r = a.comp(b, func(i, j T) bool {
+		return lt(j, i) || i == j
 	})
 	return
 
 }
 
-func (a *Array64) compValid(b *Array64, mthd string) (r *Arrayb) {
+func (a *Array[T]) compValid(b *Array[T], mthd string) (r *Arrayb) {
 
 	switch {
 	case a == nil || a.data == nil && a.err == nil:
@@ -142,24 +142,27 @@ func (a *Array64) compValid(b *Array64, mthd string) (r *Arrayb) {
 }
 
 // Validation and error checks must be complete before calling comp
-func (a *Array64) comp(b *Array64, f func(i, j float64) bool) (r *Arrayb) {
+func (a *Array[T]) comp(b *Array[T], f func(i, j T) bool) (r *Arrayb) {
 	r = newArrayB(b.shape...)
 
+	ad, bd := a.flat(), b.flat()
 	for i := range r.data {
-		r.data[i] = f(a.data[i], b.data[i])
+		r.data[i] = f(ad[i], bd[i])
 	}
 
 	return
 }
 
 // Any will return true if any element is non-zero, false otherwise.
-func (a *Arrayb) Any(axis ...int) *Arrayb {
-	if a.valAxis(&axis, "All") {
-		return a
+// NaN values are non-zero.  A new array is returned.
+func (a *Array[T]) Any(axis ...int) *Arrayb {
+	if a.valAxis(&axis, "Any") {
+		return errTo[bool](a)
 	}
 
+	b := a.AsBool()
 	if len(axis) == 0 {
-		for _, v := range a.data {
+		for _, v := range b.data {
 			if v {
 				return Fullb(true, 1)
 			}
@@ -168,22 +171,22 @@ func (a *Arrayb) Any(axis ...int) *Arrayb {
 	}
 
 	sort.IntSlice(axis).Sort()
-	n := make([]int, len(a.shape)-len(axis))
+	n := make([]int, len(b.shape)-len(axis))
 axis:
-	for i, t := 0, 0; i < len(a.shape); i++ {
+	for i, t := 0, 0; i < len(b.shape); i++ {
 		for _, w := range axis {
 			if i == w {
 				continue axis
 			}
 		}
-		n[t] = a.shape[i]
+		n[t] = b.shape[i]
 		t++
 	}
 
-	t := a.data
+	t := b.data
 	for i := 0; i < len(axis); i++ {
 
-		maj, min := a.strides[axis[i]], a.strides[axis[i]]/a.shape[axis[i]]
+		maj, min := b.strides[axis[i]], b.strides[axis[i]]/b.shape[axis[i]]
 
 		for j := 0; j+maj <= len(t); j += maj {
 			for k := j; k < j+min; k++ {
@@ -200,28 +203,29 @@ axis:
 
 		t = append(t[:0], t[0:j*min]...)
 	}
-	a.data = t
-	a.shape = n
+	b.data = t
+	b.shape = n
 
 	tmp := 1
 	for i := len(n); i > 0; i-- {
-		a.strides[i] = tmp
+		b.strides[i] = tmp
 		tmp *= n[i-1]
 	}
-	a.strides[0] = tmp
-	a.strides = a.strides[0 : len(n)+1]
-	return a
+	b.strides[0] = tmp
+	b.strides = b.strides[0 : len(n)+1]
+	return b
 }
 
-// All will return true if all elements are non-zero, false otherwise.
-func (a *Arrayb) All(axis ...int) *Arrayb {
-
+// All will return true if all element is non-zero, false otherwise.
+// NaN values are non-zero.  A new array is returned.
+func (a *Array[T]) All(axis ...int) *Arrayb {
 	if a.valAxis(&axis, "All") {
-		return a
+		return errTo[bool](a)
 	}
 
+	b := a.AsBool()
 	if len(axis) == 0 {
-		for _, v := range a.data {
+		for _, v := range b.data {
 			if !v {
 				return Fullb(false, 1)
 			}
@@ -230,22 +234,22 @@ func (a *Arrayb) All(axis ...int) *Arrayb {
 	}
 
 	sort.IntSlice(axis).Sort()
-	n := make([]int, len(a.shape)-len(axis))
+	n := make([]int, len(b.shape)-len(axis))
 axis:
-	for i, t := 0, 0; i < len(a.shape); i++ {
+	for i, t := 0, 0; i < len(b.shape); i++ {
 		for _, w := range axis {
 			if i == w {
 				continue axis
 			}
 		}
-		n[t] = a.shape[i]
+		n[t] = b.shape[i]
 		t++
 	}
 
-	t := a.data
+	t := b.data
 	for i := 0; i < len(axis); i++ {
 
-		maj, min := a.strides[axis[i]], a.strides[axis[i]]/a.shape[axis[i]]
+		maj, min := b.strides[axis[i]], b.strides[axis[i]]/b.shape[axis[i]]
 
 		for j := 0; j+maj <= len(t); j += maj {
 			for k := j; k < j+min; k++ {
@@ -257,141 +261,20 @@ axis:
 
 		j := 1
 		for ; j < len(t)/maj; j++ {
-			a := t[j*min : (j+1)*min]
-			b := t[j*maj : j*maj+min]
-			copy(a, b)
+			copy(t[j*min:(j+1)*min], t[j*maj:j*maj+min])
 		}
 
 		t = append(t[:0], t[0:j*min]...)
 	}
-	a.data = t
-	a.shape = n
+	b.data = t
+	b.shape = n
 
 	tmp := 1
 	for i := len(n); i > 0; i-- {
-		a.strides[i] = tmp
+		b.strides[i] = tmp
 		tmp *= n[i-1]
 	}
-	a.strides[0] = tmp
-	a.strides = append(a.strides[:0], a.strides[0:len(n)+1]...)
-	return a
-}
-
-func (a *Arrayb) valAxis(axis *[]int, mthd string) bool {
-	axis = cleanAxis(axis)
-	switch {
-	case a == nil || a.err != nil:
-		return true
-	case len(*axis) > len(a.shape):
-		a.err = ShapeError
-		if debug {
-			a.debug = fmt.Sprintf("Too many axes received by %s().  Shape: %v  Axes: %v", mthd, a.shape, axis)
-			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
-		}
-		return true
-	}
-	for _, v := range *axis {
-		if v < 0 || v >= len(a.shape) {
-			a.err = IndexError
-			if debug {
-				a.debug = fmt.Sprintf("Axis out of range received by %s().  Shape: %v  Axes: %v", mthd, a.shape, axis)
-				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
-			}
-			return true
-		}
-	}
-	return false
-
-}
-
-// Equals performs boolean '==' element-wise comparison
-func (a *Arrayb) Equals(b *Arrayb) (r *Arrayb) {
-	r = a.compValid(b, "Equals()")
-	if r != nil {
-		return r
-	}
-
-	r = a.comp(b, func(i, j bool) bool {
-		return i == j
-	})
-	return
-}
-
-// NotEq performs boolean '1=' element-wise comparison
-func (a *Arrayb) NotEq(b *Arrayb) (r *Arrayb) {
-	r = a.compValid(b, "NotEq()")
-	if r != nil {
-		return r
-	}
-
-	r = a.comp(b, func(i, j bool) bool {
-		return i != j
-	})
-	return
-}
-
-func (a *Arrayb) compValid(b *Arrayb, mthd string) (r *Arrayb) {
-
-	switch {
-	case a == nil || a.data == nil && a.err == nil:
-		r = &Arrayb{err: NilError}
-		if debug {
-			r.debug = fmt.Sprintf("Nil pointer received by %s", mthd)
-			r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
-		}
-		return r
-	case b == nil || b.data == nil && b.err == nil:
-		r = &Arrayb{err: NilError}
-		if debug {
-			r.debug = fmt.Sprintf("Array received by %s is a Nil Pointer.", mthd)
-			r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
-		}
-		return r
-	case a.err != nil:
-		r = &Arrayb{err: a.err}
-		if debug {
-			r.debug = fmt.Sprintf("Error in %s arrays", mthd)
-			r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
-		}
-		return r
-	case b.err != nil:
-		r = &Arrayb{err: b.err}
-		if debug {
-			r.debug = fmt.Sprintf("Error in %s arrays", mthd)
-			r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
-		}
-		return r
-
-	case len(a.shape) < len(b.shape):
-		r = &Arrayb{err: ShapeError}
-		if debug {
-			r.debug = fmt.Sprintf("Array received by %s can not be broadcast.  Shape: %v  Val shape: %v", mthd, a.shape, b.shape)
-			r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
-		}
-		return r
-	}
-
-	for i, j := len(b.shape)-1, len(a.shape)-1; i >= 0; i, j = i-1, j-1 {
-		if a.shape[j] != b.shape[i] {
-			r = &Arrayb{err: ShapeError}
-			if debug {
-				r.debug = fmt.Sprintf("Array received by %s can not be broadcast.  Shape: %v  Val shape: %v", mthd, a.shape, b.shape)
-				r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
-			}
-			return r
-		}
-	}
-
-	return nil
-}
-
-// Validation and error checks must be complete before calling comp
-func (a *Arrayb) comp(b *Arrayb, f func(i, j bool) bool) (r *Arrayb) {
-	r = newArrayB(b.shape...)
-
-	for i := range r.data {
-		r.data[i] = f(a.data[i], b.data[i])
-	}
-
-	return
+	b.strides[0] = tmp
+	b.strides = b.strides[0 : len(n)+1]
+	return b
 }
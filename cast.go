@@ -0,0 +1,198 @@
+package numgo
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"unsafe"
+)
+
+// Rounding selects how AsType converts floating point values to integers.
+type Rounding int
+
+const (
+	// RoundTrunc rounds towards zero, the same as a Go conversion.
+	RoundTrunc Rounding = iota
+	// RoundNearest rounds to the nearest integer, with halves rounded away from zero.
+	RoundNearest
+	// RoundHalfEven rounds to the nearest integer, with halves rounded to the even integer.
+	RoundHalfEven
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+	// RoundCeil rounds towards positive infinity.
+	RoundCeil
+)
+
+// apply rounds f to an integer value.
+func (r Rounding) apply(f float64) float64 {
+	switch r {
+	case RoundNearest:
+		return math.Round(f)
+	case RoundHalfEven:
+		return math.RoundToEven(f)
+	case RoundFloor:
+		return math.Floor(f)
+	case RoundCeil:
+		return math.Ceil(f)
+	}
+	return math.Trunc(f)
+}
+
+// AsBool converts the array to a bool array, with true for all non-zero elements.
+// NaN values are non-zero.  A new array is returned.
+func (a *Array[T]) AsBool() *Arrayb {
+	return AsType[bool](a, RoundTrunc, false)
+}
+
+// AsFloat64 converts the array to a float64 array.  true values become 1, and
+// complex values keep their real part.  A new array is returned.
+func (a *Array[T]) AsFloat64() *Array64 {
+	return AsType[float64](a, RoundTrunc, false)
+}
+
+// AsType converts the array to element type U.  A new array is returned.
+//
+// Floating point values are rounded to integer types using the round mode.
+// When checked is set, any value that can't be represented in U generates a CastError:
+// integers out of the range of U, NaN and infinite values converted to integers,
+// finite values that overflow a smaller floating point type, and complex values with
+// a non-zero imaginary part converted to a real type.
+// Unchecked conversions wrap integers around, convert NaN to zero, and drop the imaginary part.
+func AsType[U, T Elem](a *Array[T], round Rounding, checked bool) (r *Array[U]) {
+	if a.HasErr() {
+		return errTo[U](a)
+	}
+
+	src, dst := kern[T](), kern[U]()
+	r = newArray[U](a.shape...)
+	for i, v := range a.flat() {
+		var ok bool
+		if r.data[i], ok = dst.store(src.load(v), round); !ok && checked {
+			r = &Array[U]{err: CastError}
+			if debug {
+				r.debug = fmt.Sprintf("Value %v received by AsType() can not be represented as %s.  Index: %d", v, dst.name, i)
+				r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return r
+		}
+	}
+	return r
+}
+
+// scalar holds an element converted to the widest type of its kind, so AsType
+// can convert between any two element types without an intermediate loss of precision.
+type scalar struct {
+	kind byte // 'i' signed, 'u' unsigned, 'f' floating point, 'c' complex
+	i    int64
+	u    uint64
+	f    float64
+	c    complex128
+}
+
+// float returns the real value of a scalar, and whether it had no imaginary part.
+func (s scalar) float() (float64, bool) {
+	switch s.kind {
+	case 'i':
+		return float64(s.i), true
+	case 'u':
+		return float64(s.u), true
+	case 'c':
+		return real(s.c), imag(s.c) == 0
+	}
+	return s.f, true
+}
+
+// intCast creates the conversion functions of an integer type.
+func intCast[T Integer]() (load func(T) scalar, store func(scalar, Rounding) (T, bool)) {
+	var zero T
+	signed := zero-1 < 0
+	bits := unsafe.Sizeof(zero) * 8
+
+	// Valid values are in [lo, hi).
+	lo, hi := 0.0, math.Ldexp(1, int(bits))
+	if signed {
+		lo, hi = -math.Ldexp(1, int(bits)-1), math.Ldexp(1, int(bits)-1)
+	}
+
+	load = func(v T) scalar {
+		if signed {
+			return scalar{kind: 'i', i: int64(v)}
+		}
+		return scalar{kind: 'u', u: uint64(v)}
+	}
+	store = func(s scalar, r Rounding) (T, bool) {
+		switch s.kind {
+		case 'i':
+			t := T(s.i)
+			return t, int64(t) == s.i && (t < 0) == (s.i < 0)
+		case 'u':
+			t := T(s.u)
+			return t, uint64(t) == s.u && t >= 0
+		}
+
+		f, ok := s.float()
+		switch f = r.apply(f); {
+		case f != f:
+			return 0, false
+		case f >= -math.Ldexp(1, 63) && f < math.Ldexp(1, 63):
+			return T(int64(f)), ok && f >= lo && f < hi
+		case f >= 0 && f < math.Ldexp(1, 64):
+			return T(uint64(f)), ok && f >= lo && f < hi
+		}
+		return 0, false
+	}
+	return load, store
+}
+
+// floatCast creates the conversion functions of a floating point type.
+func floatCast[T Float]() (load func(T) scalar, store func(scalar, Rounding) (T, bool)) {
+	load = func(v T) scalar {
+		return scalar{kind: 'f', f: float64(v)}
+	}
+	store = func(s scalar, _ Rounding) (T, bool) {
+		f, ok := s.float()
+		t := T(f)
+		return t, ok && (!math.IsInf(float64(t), 0) || math.IsInf(f, 0))
+	}
+	return load, store
+}
+
+// complexCast creates the conversion functions of a complex type.
+func complexCast[T Complex]() (load func(T) scalar, store func(scalar, Rounding) (T, bool)) {
+	load = func(v T) scalar {
+		return scalar{kind: 'c', c: complex128(v)}
+	}
+	store = func(s scalar, _ Rounding) (T, bool) {
+		c := s.c
+		if s.kind != 'c' {
+			f, _ := s.float()
+			c = complex(f, 0)
+		}
+		t := complex128(T(c))
+		inf := func(f, g float64) bool { return !math.IsInf(f, 0) || math.IsInf(g, 0) }
+		return T(c), inf(real(t), real(c)) && inf(imag(t), imag(c))
+	}
+	return load, store
+}
+
+// boolCast creates the conversion functions of the bool type.
+func boolCast() (load func(bool) scalar, store func(scalar, Rounding) (bool, bool)) {
+	load = func(v bool) scalar {
+		if v {
+			return scalar{kind: 'i', i: 1}
+		}
+		return scalar{kind: 'i'}
+	}
+	store = func(s scalar, _ Rounding) (bool, bool) {
+		switch s.kind {
+		case 'i':
+			return s.i != 0, true
+		case 'u':
+			return s.u != 0, true
+		case 'c':
+			return s.c != 0, true
+		}
+		return s.f != 0, true
+	}
+	return load, store
+}
@@ -0,0 +1,114 @@
+package numgo
+
+import (
+	"math"
+	"testing"
+)
+
+func init() {
+	debug = true
+}
+
+func TestAsBool(t *testing.T) {
+	a := NewArray64([]float64{0, 1, -2, math.NaN(), 0, 0.5}, 2, 3)
+	for i, v := range []struct {
+		a   *Arrayb
+		res *Arrayb
+		err error
+	}{
+		{a.AsBool(), NewArrayB([]bool{false, true, true, true, false, true}, 2, 3), nil},
+		{a.T().AsBool(), NewArrayB([]bool{false, true, true, false, true, true}, 3, 2), nil},
+		{NewArray([]int8{0, -1}).AsBool(), NewArrayB([]bool{false, true}), nil},
+		{NewArray([]complex64{1i, 0}).AsBool(), NewArrayB([]bool{true, false}), nil},
+		{Fullb(true, 2).AsBool(), Fullb(true, 2), nil},
+		{(&Array64{err: ShapeError}).AsBool(), nil, ShapeError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !v.a.Equals(v.res).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Got", v.a)
+			t.Fail()
+		}
+	}
+}
+
+func TestAsFloat64(t *testing.T) {
+	for i, v := range []struct {
+		a   *Array64
+		res []float64
+		err error
+	}{
+		{NewArrayB([]bool{true, false, true}).AsFloat64(), []float64{1, 0, 1}, nil},
+		{NewArray([]int64{math.MinInt64, 7}).AsFloat64(), []float64{math.MinInt64, 7}, nil},
+		{NewArray([]uint32{math.MaxUint32}).AsFloat64(), []float64{math.MaxUint32}, nil},
+		{NewArray([]float32{1.5, float32(math.Inf(-1))}).AsFloat64(), []float64{1.5, math.Inf(-1)}, nil},
+		{NewArray([]complex128{2 + 3i}).AsFloat64(), []float64{2}, nil},
+		{(&Arrayb{err: IndexError}).AsFloat64(), nil, IndexError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !v.a.Equals(NewArray64(v.res)).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Got", v.a)
+			t.Fail()
+		}
+	}
+}
+
+func TestAsType(t *testing.T) {
+	f := NewArray64([]float64{-2.5, -0.5, 0.5, 1.5, 2.7})
+	for i, v := range []struct {
+		a   *ArrayI32
+		res []int32
+		err error
+	}{
+		{AsType[int32](f, RoundTrunc, true), []int32{-2, 0, 0, 1, 2}, nil},
+		{AsType[int32](f, RoundNearest, true), []int32{-3, -1, 1, 2, 3}, nil},
+		{AsType[int32](f, RoundHalfEven, true), []int32{-2, 0, 0, 2, 3}, nil},
+		{AsType[int32](f, RoundFloor, true), []int32{-3, -1, 0, 1, 2}, nil},
+		{AsType[int32](f, RoundCeil, true), []int32{-2, 0, 1, 2, 3}, nil},
+		{AsType[int32](NewArray64([]float64{math.NaN()}), RoundTrunc, false), []int32{0}, nil},
+		{AsType[int32](NewArray64([]float64{math.NaN()}), RoundTrunc, true), nil, CastError},
+		{AsType[int32](NewArray64([]float64{math.Inf(1)}), RoundTrunc, true), nil, CastError},
+		{AsType[int32](NewArray64([]float64{1 << 31}), RoundTrunc, true), nil, CastError},
+		{AsType[int32](NewArray64([]float64{-1 << 31}), RoundTrunc, true), []int32{math.MinInt32}, nil},
+		{AsType[int32](NewArray([]int64{1<<32 + 5}), RoundTrunc, false), []int32{5}, nil},
+		{AsType[int32](NewArray([]int64{1<<32 + 5}), RoundTrunc, true), nil, CastError},
+		{AsType[int32](NewArray([]uint64{math.MaxUint32}), RoundTrunc, true), nil, CastError},
+		{AsType[int32](NewArray([]complex128{2 + 1i}), RoundTrunc, false), []int32{2}, nil},
+		{AsType[int32](NewArray([]complex128{2 + 1i}), RoundTrunc, true), nil, CastError},
+		{AsType[int32](Fullb(true, 2), RoundTrunc, true), []int32{1, 1}, nil},
+		{AsType[int32](&Array64{err: ReshapeError}, RoundTrunc, true), nil, ReshapeError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !v.a.Equals(NewArray(v.res)).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Got", v.a)
+			t.Fail()
+		}
+	}
+
+	for i, ok := range []bool{
+		AsType[uint8](NewArray([]int16{-1}), RoundTrunc, false).At(0) == 255,
+		AsType[uint8](NewArray([]int16{-1}), RoundTrunc, true).HasErr(),
+		AsType[uint64](NewArray([]float64{1 << 63}), RoundTrunc, true).At(0) == 1<<63,
+		AsType[int64](NewArray([]uint64{1 << 63}), RoundTrunc, true).HasErr(),
+		AsType[float32](NewArray64([]float64{1e39}), RoundTrunc, true).HasErr(),
+		math.IsInf(float64(AsType[float32](NewArray64([]float64{math.Inf(1)}), RoundTrunc, true).At(0)), 1),
+		AsType[complex64](NewArray([]int8{-3}), RoundTrunc, true).At(0) == -3,
+		AsType[complex128](NewArray([]complex64{1 + 2i}), RoundTrunc, true).At(0) == 1+2i,
+	} {
+		if !ok {
+			t.Log("Test", i, "failed")
+			t.Fail()
+		}
+	}
+}
@@ -2,19 +2,22 @@ package numgo
 
 import (
 	"fmt"
+	"math"
 	"runtime"
+	"sort"
 )
 
 // Max will return the maximum along the given axes.
-func (a *Array64) Max(axis ...int) (r *Array64) {
+func (a *Array[T]) Max(axis ...int) (r *Array[T]) {
 	if a.valAxis(&axis, "Max") {
 		return a
 	}
 
-	max := func(d []float64) (r float64) {
+	lt := kern[T]().lt
+	max := func(d []T) (r T) {
 		r = d[0]
 		for _, v := range d {
-			if v > r {
+			if lt(r, v) {
 				r = v
 			}
 		}
@@ -27,15 +30,16 @@ func (a *Array64) Max(axis ...int) (r *Array64) {
 }
 
 // Min will return the minimum along the given axes.
-func (a *Array64) Min(axis ...int) (r *Array64) {
+func (a *Array[T]) Min(axis ...int) (r *Array[T]) {
 	if a.valAxis(&axis, "Max") {
 		return a
 	}
 
-	min := func(d []float64) (r float64) {
+	lt := kern[T]().lt
+	min := func(d []T) (r T) {
 		r = d[0]
 		for _, v := range d {
-			if v < r {
+			if lt(v, r) {
 				r = v
 			}
 		}
@@ -47,20 +51,165 @@ func (a *Array64) Min(axis ...int) (r *Array64) {
 	return r
 }
 
+// ArgMax will return the index of the maximum along the given axes.
+// The first occurrence is returned when the maximum is repeated, and NaN values are treated as the maximum.
+//
+// Indices count through the given axes in row-major order, in ascending axis order.
+// An empty call gives the flat index in the whole array.
+func (a *Array[T]) ArgMax(axis ...int) *Array64 {
+	if a.valAxis(&axis, "ArgMax") {
+		return errTo[float64](a)
+	}
+
+	lt := kern[T]().lt
+	return a.foldIdx(func(d []T) int {
+		m := 0
+		for i, v := range d {
+			switch {
+			case v != v:
+				return i
+			case lt(d[m], v):
+				m = i
+			}
+		}
+		return m
+	}, axis, "ArgMax")
+}
+
+// ArgMin will return the index of the minimum along the given axes.
+// The first occurrence is returned when the minimum is repeated, and NaN values are treated as the minimum.
+//
+// Indices count through the given axes in row-major order, in ascending axis order.
+// An empty call gives the flat index in the whole array.
+func (a *Array[T]) ArgMin(axis ...int) *Array64 {
+	if a.valAxis(&axis, "ArgMin") {
+		return errTo[float64](a)
+	}
+
+	lt := kern[T]().lt
+	return a.foldIdx(func(d []T) int {
+		m := 0
+		for i, v := range d {
+			switch {
+			case v != v:
+				return i
+			case lt(v, d[m]):
+				m = i
+			}
+		}
+		return m
+	}, axis, "ArgMin")
+}
+
+// NaNArgMax will return the index of the maximum along the given axes, ignoring NaN values.
+// If all element values along the axes are NaN, NaN is in the return element.
+//
+// Indices are counted the same way as ArgMax.
+func (a *Array[T]) NaNArgMax(axis ...int) *Array64 {
+	if a.valAxis(&axis, "NaNArgMax") {
+		return errTo[float64](a)
+	}
+
+	lt := kern[T]().lt
+	return a.foldIdx(func(d []T) int {
+		m := -1
+		for i, v := range d {
+			if v == v && (m < 0 || lt(d[m], v)) {
+				m = i
+			}
+		}
+		return m
+	}, axis, "NaNArgMax")
+}
+
+// NaNArgMin will return the index of the minimum along the given axes, ignoring NaN values.
+// If all element values along the axes are NaN, NaN is in the return element.
+//
+// Indices are counted the same way as ArgMin.
+func (a *Array[T]) NaNArgMin(axis ...int) *Array64 {
+	if a.valAxis(&axis, "NaNArgMin") {
+		return errTo[float64](a)
+	}
+
+	lt := kern[T]().lt
+	return a.foldIdx(func(d []T) int {
+		m := -1
+		for i, v := range d {
+			if v == v && (m < 0 || lt(v, d[m])) {
+				m = i
+			}
+		}
+		return m
+	}, axis, "NaNArgMin")
+}
+
+// foldIdx applies f across the elements of the given axes, which must be validated before calling.
+// f returns the index of an element within the slice it receives, or -1 for a NaN result.
+func (a *Array[T]) foldIdx(f func([]T) int, axis []int, mthd string) *Array64 {
+	// The reduced axes are moved to the end, in ascending order, so each group of elements is contiguous.
+	ax := append([]int(nil), axis...)
+	sort.Ints(ax)
+	perm, span := make([]int, 0, len(a.shape)), 1
+keep:
+	for i := range a.shape {
+		for _, w := range ax {
+			if i == w {
+				continue keep
+			}
+		}
+		perm = append(perm, i)
+	}
+	sh := make([]int, 0, len(perm))
+	for _, v := range perm {
+		sh = append(sh, a.shape[v])
+	}
+	for _, w := range ax {
+		span *= a.shape[w]
+	}
+	if len(ax) == 0 {
+		sh, span = []int{1}, a.strides[0]
+	}
+
+	if span == 0 && size(sh) > 0 {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Empty axes received by %s().  Shape: %v  Axes: %v", mthd, a.shape, axis)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	}
+
+	d := a.flat()
+	if len(ax) > 0 {
+		d = a.permute(append(perm, ax...)).data
+	}
+	r := newArray64(sh...)
+	for i := range r.data {
+		if m := f(d[i*span : (i+1)*span]); m >= 0 {
+			r.data[i] = float64(m)
+		} else {
+			r.data[i] = math.NaN()
+		}
+	}
+	return r
+}
+
 // MaxSet will return the element-wise maximum of arrays.
 //
 // All arrays must be the non-nil and the same shape.
-func MaxSet(arrSet ...*Array64) (b *Array64) {
+func MaxSet[T Elem](arrSet ...*Array[T]) (b *Array[T]) {
 	if b = b.valSet(arrSet, "MaxSet"); b != nil {
 		return b
 	}
 
 	b = arrSet[0].C()
 
+	lt := kern[T]().lt
 	for j := 1; j < len(arrSet); j++ {
+		d := arrSet[j].flat()
 		for i := range b.data {
-			if arrSet[j].data[i] > b.data[i] {
-				b.data[i] = arrSet[j].data[i]
+			if lt(b.data[i], d[i]) {
+				b.data[i] = d[i]
 			}
 		}
 	}
@@ -70,27 +219,29 @@ func MaxSet(arrSet ...*Array64) (b *Array64) {
 // MinSet will return the element-wise maximum of arrays.
 //
 // All arrays must be the non-nil and the same shape.
-func MinSet(arrSet ...*Array64) (b *Array64) {
+func MinSet[T Elem](arrSet ...*Array[T]) (b *Array[T]) {
 	if b = b.valSet(arrSet, "MaxSet"); b != nil {
 		return b
 	}
 
 	b = arrSet[0].C()
 
+	lt := kern[T]().lt
 	for j := 1; j < len(arrSet); j++ {
+		d := arrSet[j].flat()
 		for i := range b.data {
-			if arrSet[j].data[i] < b.data[i] {
-				b.data[i] = arrSet[j].data[i]
+			if lt(d[i], b.data[i]) {
+				b.data[i] = d[i]
 			}
 		}
 	}
 	return
 }
 
-func (a *Array64) valSet(arrSet []*Array64, mthd string) (b *Array64) {
+func (a *Array[T]) valSet(arrSet []*Array[T], mthd string) (b *Array[T]) {
 
 	if len(arrSet) == 0 {
-		b = &Array64{err: NilError}
+		b = &Array[T]{err: NilError}
 		if debug {
 			b.debug = mthd + "() called with no arrays"
 			b.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
@@ -101,7 +252,7 @@ func (a *Array64) valSet(arrSet []*Array64, mthd string) (b *Array64) {
 	a = arrSet[0]
 	for _, v := range arrSet {
 		if v == nil {
-			b = &Array64{err: NilError}
+			b = &Array[T]{err: NilError}
 			if debug {
 				b.debug = mthd + "() received a Nil pointer array as an argument."
 				b.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
@@ -109,7 +260,7 @@ func (a *Array64) valSet(arrSet []*Array64, mthd string) (b *Array64) {
 			return b
 		}
 		if v.err != nil {
-			b = &Array64{err: v.err}
+			b = &Array[T]{err: v.err}
 			if debug {
 				b.debug = "Error in data passed to " + mthd + "()."
 				b.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
@@ -119,7 +270,7 @@ func (a *Array64) valSet(arrSet []*Array64, mthd string) (b *Array64) {
 
 		for k, s := range v.shape {
 			if s != a.shape[k] {
-				b = &Array64{err: ShapeError}
+				b = &Array[T]{err: ShapeError}
 				if debug {
 					b.debug = fmt.Sprintf("Array received by %s() does not match shape.  Shape: %v  Val shape: %v", mthd, a.shape, v.shape)
 					b.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
@@ -1,6 +1,9 @@
 package numgo
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func init() {
 	debug = true
@@ -130,3 +133,68 @@ func TestMaxSet(t *testing.T) {
 	}
 
 }
+
+func TestArgMaxMin(t *testing.T) {
+	a := NewArray64([]float64{3, 7, 1, 9, 5, 2, 8, 0, 6, 4, 2, 8}, 2, 3, 2)
+	nan := NewArray64([]float64{1, math.NaN(), 3, math.NaN()}, 2, 2)
+
+	tests := []struct {
+		f   func(*Array64, ...int) *Array64
+		a   *Array64
+		ax  []int
+		res []float64
+		sh  []int
+		err error
+	}{
+		{(*Array64).ArgMax, a, []int{}, []float64{3}, []int{1}, nil},
+		{(*Array64).ArgMax, a, []int{0}, []float64{1, 0, 1, 0, 0, 1}, []int{3, 2}, nil},
+		{(*Array64).ArgMax, a, []int{1}, []float64{2, 1, 0, 2}, []int{2, 2}, nil},
+		{(*Array64).ArgMax, a, []int{2}, []float64{1, 1, 0, 0, 0, 1}, []int{2, 3}, nil},
+		{(*Array64).ArgMax, a, []int{0, 2}, []float64{2, 1, 3}, []int{3}, nil},
+		{(*Array64).ArgMax, a, []int{2, 0}, []float64{2, 1, 3}, []int{3}, nil},
+		{(*Array64).ArgMax, a, []int{0, 1, 2}, []float64{3}, []int{1}, nil},
+		{(*Array64).ArgMax, NewArray64([]float64{2, 5, 5}), []int{}, []float64{1}, []int{1}, nil},
+		{(*Array64).ArgMax, nan, []int{1}, []float64{1, 1}, []int{2}, nil},
+		{(*Array64).ArgMax, cols(Arange(12).Reshape(4, 3), 1), []int{0}, []float64{3}, []int{1}, nil},
+		{(*Array64).ArgMin, a, []int{}, []float64{7}, []int{1}, nil},
+		{(*Array64).ArgMin, a, []int{1}, []float64{1, 2, 2, 0}, []int{2, 2}, nil},
+		{(*Array64).ArgMin, NewArray64([]float64{2, 1, 1}), []int{}, []float64{1}, []int{1}, nil},
+		{(*Array64).ArgMin, nan, []int{0}, []float64{0, 0}, []int{2}, nil},
+		{(*Array64).NaNArgMax, nan, []int{1}, []float64{0, 0}, []int{2}, nil},
+		{(*Array64).NaNArgMax, nan, []int{0}, []float64{1, math.NaN()}, []int{2}, nil},
+		{(*Array64).NaNArgMax, a, []int{1}, []float64{2, 1, 0, 2}, []int{2, 2}, nil},
+		{(*Array64).NaNArgMin, nan, []int{}, []float64{0}, []int{1}, nil},
+		{(*Array64).NaNArgMin, nan, []int{0}, []float64{0, math.NaN()}, []int{2}, nil},
+		{(*Array64).ArgMax, a, []int{3}, nil, nil, IndexError},
+		{(*Array64).ArgMin, a, []int{0, 1, 2, 0, 1}, nil, nil, nil},
+		{(*Array64).NaNArgMin, NewArray64(nil, 2, 0), []int{1}, nil, nil, ShapeError},
+		{(*Array64).NaNArgMax, nil, []int{}, nil, nil, NilError},
+	}
+	for i, v := range tests {
+		var a *Array64
+		if v.a != nil {
+			a = v.a.C()
+		}
+		r := v.f(a, v.ax...)
+		if e := r.GetErr(); e != v.err {
+			t.Logf("Test %d Error Failed: Expected %#v got %#v\n", i, v.err, e)
+			t.Fail()
+			continue
+		}
+		if v.res == nil {
+			continue
+		}
+		if !equalShape(r.shape, v.sh) {
+			t.Logf("Test %d Failed: Expected shape %v got %v\n", i, v.sh, r.shape)
+			t.Fail()
+			continue
+		}
+		for j, e := range v.res {
+			if r.data[j] != e && !(math.IsNaN(e) && math.IsNaN(r.data[j])) {
+				t.Logf("Test %d Failed: Expected %v got %v\n", i, v.res, r.data)
+				t.Fail()
+				break
+			}
+		}
+	}
+}
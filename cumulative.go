@@ -0,0 +1,200 @@
+package numgo
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// cumulate accumulates the elements along axis in place, with each element combined with the accumulated element before it.
+// Inner axes are contiguous blocks, so they're accumulated a block at a time with vec.
+// When the axis is the inner-most axis, each run of elements is accumulated by scan,
+// or element by element with op when scan is nil.
+func (a *Array[T]) cumulate(axis int, mthd string, op func(x, prev T) T, vec func(d, prev []T), scan func(d []T)) *Array[T] {
+	if a.valAxis(&[]int{axis}, mthd) {
+		return a
+	}
+
+	m, ln := a.shape[axis], size(a.shape[axis+1:])
+	a.apply(func(d []T) {
+		for o := 0; o < len(d); o += m * ln {
+			switch {
+			case ln == 1 && scan != nil:
+				scan(d[o : o+m])
+			case ln == 1:
+				for j := o + 1; j < o+m; j++ {
+					d[j] = op(d[j], d[j-1])
+				}
+			default:
+				for j := o + ln; j < o+m*ln; j += ln {
+					vec(d[j:j+ln], d[j-ln:j])
+				}
+			}
+		}
+	})
+	return a
+}
+
+// scanMin is the shortest run of elements that scanSum splits into blocks.
+const scanMin = 256
+
+// scanSum calculates the running sum of d in place.
+//
+// Each sum depends on the one before it, so a serial loop is limited by the latency of addition.
+// Long runs are split into four blocks.  The totals of the first three blocks are calculated together,
+// giving the carry into each block, then the running sums of all four blocks are calculated together
+// from their carries.  Both passes run independent chains of additions, which the CPU overlaps.
+// Elements past the last block are summed serially.
+//
+// Carries are added in a different order than a serial sum, so floating point results may differ in rounding.
+func scanSum[T Numeric](d []T) {
+	if len(d) < scanMin {
+		for j := 1; j < len(d); j++ {
+			d[j] += d[j-1]
+		}
+		return
+	}
+
+	ln := len(d) / 4
+	b0, b1, b2, b3 := d[:ln], d[ln:2*ln], d[2*ln:3*ln], d[3*ln:4*ln]
+	var t0, t1, t2 T
+	for i := range b0 {
+		t0 += b0[i]
+		t1 += b1[i]
+		t2 += b2[i]
+	}
+
+	c0, c1 := T(0), t0
+	c2 := c1 + t1
+	c3 := c2 + t2
+	for i := range b0 {
+		c0 += b0[i]
+		c1 += b1[i]
+		c2 += b2[i]
+		c3 += b3[i]
+		b0[i], b1[i], b2[i], b3[i] = c0, c1, c2, c3
+	}
+	for j := 4 * ln; j < len(d); j++ {
+		d[j] += d[j-1]
+	}
+}
+
+// CumSum calculates the cumulative sum of the elements along axis.
+// This will modify the source array.
+func (a *Array[T]) CumSum(axis int) *Array[T] {
+	k := kern[T]()
+	if a.HasErr() || a.valOp(k.vadd != nil, "CumSum") {
+		return a
+	}
+	return a.cumulate(axis, "CumSum", k.plus, k.vadd, k.cumsum)
+}
+
+// NaNCumSum calculates the cumulative sum of the elements along axis, treating NaN values as zero.
+// This will modify the source array.
+func (a *Array[T]) NaNCumSum(axis int) *Array[T] {
+	k := kern[T]()
+	if a.HasErr() || a.valOp(k.vadd != nil, "NaNCumSum") || a.valAxis(&[]int{axis}, "NaNCumSum") {
+		return a
+	}
+
+	var zero T
+	a.apply(func(d []T) {
+		for i, v := range d {
+			if v != v {
+				d[i] = zero
+			}
+		}
+	})
+	return a.cumulate(axis, "NaNCumSum", k.plus, k.vadd, k.cumsum)
+}
+
+// CumProd calculates the cumulative product of the elements along axis.
+// This will modify the source array.
+func (a *Array[T]) CumProd(axis int) *Array[T] {
+	k := kern[T]()
+	if a.HasErr() || a.valOp(k.mult != nil, "CumProd") {
+		return a
+	}
+	return a.cumulate(axis, "CumProd", k.times, k.mult, nil)
+}
+
+// CumMax calculates the running maximum of the elements along axis.
+// NaN values are carried forward, and complex values are ordered by real, then imaginary part.
+// This will modify the source array.
+func (a *Array[T]) CumMax(axis int) *Array[T] {
+	k := kern[T]()
+	if a.HasErr() {
+		return a
+	}
+
+	op := func(x, prev T) T {
+		if prev != prev || k.lt(x, prev) {
+			return prev
+		}
+		return x
+	}
+	return a.cumulate(axis, "CumMax", op, func(d, prev []T) {
+		for i := range d {
+			d[i] = op(d[i], prev[i])
+		}
+	}, nil)
+}
+
+// CumMin calculates the running minimum of the elements along axis.
+// NaN values are carried forward, and complex values are ordered by real, then imaginary part.
+// This will modify the source array.
+func (a *Array[T]) CumMin(axis int) *Array[T] {
+	k := kern[T]()
+	if a.HasErr() {
+		return a
+	}
+
+	op := func(x, prev T) T {
+		if prev != prev || k.lt(prev, x) {
+			return prev
+		}
+		return x
+	}
+	return a.cumulate(axis, "CumMin", op, func(d, prev []T) {
+		for i := range d {
+			d[i] = op(d[i], prev[i])
+		}
+	}, nil)
+}
+
+// Diff calculates the n-th discrete difference along axis, where each difference is a[i+1] - a[i].
+// Each difference shrinks the axis by one, and n can not be larger than the length of the axis.
+// This will modify the source array.
+func (a *Array[T]) Diff(n, axis int) *Array[T] {
+	k := kern[T]()
+	switch {
+	case a.HasErr(), a.valOp(k.subtr != nil, "Diff"), a.valAxis(&[]int{axis}, "Diff"):
+		return a
+	case n < 0 || n > a.shape[axis]:
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Diff() can not take %d differences along axis %d.  Shape: %v", n, axis, a.shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	case n == 0:
+		return a
+	}
+
+	sh, ln := append([]int{}, a.shape...), size(a.shape[axis+1:])
+	d := a.flat()
+	for ; n > 0; n-- {
+		m := sh[axis]
+		r := make([]T, len(d)/m*(m-1))
+		for o, p := 0, 0; o < len(d); o, p = o+m*ln, p+(m-1)*ln {
+			for j := 0; j < (m-1)*ln; j += ln {
+				copy(r[p+j:p+j+ln], d[o+j+ln:o+j+2*ln])
+				k.subtr(r[p+j:p+j+ln], d[o+j:o+j+ln])
+			}
+		}
+		d, sh[axis] = r, m-1
+	}
+
+	a.shape, a.strides, a.data = sh, append([]int{len(d)}, rowStrides(sh)...), d
+	a.offset, a.shared = 0, false
+	return a
+}
@@ -0,0 +1,165 @@
+package numgo
+
+import (
+	"math"
+	"testing"
+)
+
+// sameNaN tests that two arrays hold the same shape and values, where NaN values match.
+func sameNaN(a, b *Array64) bool {
+	if !equalShape(a.shape, b.shape) {
+		return false
+	}
+	bd := b.Values()
+	for i, v := range a.Values() {
+		if v != bd[i] && !(v != v && bd[i] != bd[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCumulative(t *testing.T) {
+	t.Parallel()
+	nan := math.NaN()
+	a := Arange(1, 6).Reshape(2, 3)
+	for i, v := range []struct {
+		a, res *Array64
+	}{
+		{Arange(1, 5).CumSum(0), NewArray64([]float64{1, 3, 6, 10, 15})},
+		{a.C().CumSum(0), NewArray64([]float64{1, 2, 3, 5, 7, 9}, 2, 3)},
+		{a.C().CumSum(1), NewArray64([]float64{1, 3, 6, 4, 9, 15}, 2, 3)},
+		{a.C().T().CumSum(0), NewArray64([]float64{1, 4, 3, 9, 6, 15}, 3, 2)},
+		{Arange(24).Reshape(2, 3, 4).CumSum(1).Reshape(6, 4).SubArr(5), NewArray64([]float64{48, 51, 54, 57})},
+		{a.C().CumProd(1), NewArray64([]float64{1, 2, 6, 4, 20, 120}, 2, 3)},
+		{a.C().CumProd(0), NewArray64([]float64{1, 2, 3, 4, 10, 18}, 2, 3)},
+		{NewArray64([]float64{1, 3, 2, 5, 4}).CumMax(0), NewArray64([]float64{1, 3, 3, 5, 5})},
+		{NewArray64([]float64{3, 1, 2, 0, 4}).CumMin(0), NewArray64([]float64{3, 1, 1, 0, 0})},
+		{NewArray64([]float64{1, nan, 2, 5}).CumMax(0), NewArray64([]float64{1, nan, nan, nan})},
+		{NewArray64([]float64{1, 5, 2, nan, 0, 6}, 3, 2).CumMin(0), NewArray64([]float64{1, 5, 1, nan, 0, nan}, 3, 2)},
+		{NewArray64([]float64{1, 5, 2, 0, 0, 6}, 3, 2).CumMax(0), NewArray64([]float64{1, 5, 2, 5, 2, 6}, 3, 2)},
+		{NewArray64([]float64{1, nan, 2, 5}).CumSum(0), NewArray64([]float64{1, nan, nan, nan})},
+		{NewArray64([]float64{1, nan, 2, 5}).NaNCumSum(0), NewArray64([]float64{1, 1, 3, 8})},
+		{NewArray64([]float64{nan, 1, 2, nan}, 2, 2).NaNCumSum(0), NewArray64([]float64{0, 1, 2, 1}, 2, 2)},
+	} {
+		if e := v.a.GetErr(); e != nil {
+			t.Log("Test", i, "Unexpected error", e)
+			t.Fail()
+			continue
+		}
+		if !sameNaN(v.a, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Received", v.a)
+			t.Fail()
+		}
+	}
+
+	// Views are accumulated in the source data.
+	b := Arange(1, 6).Reshape(2, 3)
+	b.SubArr(1).CumSum(0)
+	if !b.Equals(NewArray64([]float64{1, 2, 3, 4, 9, 15}, 2, 3)).All().At(0) {
+		t.Log("View failed", b)
+		t.Fail()
+	}
+
+	// Long inner axes are summed in blocks by scanSum, and must match a serial sum.
+	for _, ln := range []int{scanMin - 1, scanMin, scanMin + 3, 10007} {
+		x := RandArray64(-100, 100, 3, ln).Map(math.Floor)
+		x.Set(nan, 1, ln/2)
+		exp := x.C()
+		for i := 0; i < 3; i++ {
+			for j := 1; j < ln; j++ {
+				exp.Set(exp.At(i, j)+exp.At(i, j-1), i, j)
+			}
+		}
+		if c := x.CumSum(1); !sameNaN(c, exp) {
+			t.Log("Block CumSum of length", ln, "does not match serial sum")
+			t.Fail()
+		}
+		n := NewArray[int32](nil, ln).AddC(-3)
+		n.Set(7, ln-1)
+		if c := n.CumSum(0); c.At(ln-2) != int32(-3*(ln-1)) || c.At(ln-1) != int32(-3*(ln-1)+7) {
+			t.Log("Int block CumSum of length", ln, "failed", c.At(ln-2), c.At(ln-1))
+			t.Fail()
+		}
+	}
+
+	if c := NewArray[int16]([]int16{2, 3, 4}, 3).CumProd(0); c.At(2) != 24 {
+		t.Log("Int CumProd failed", c)
+		t.Fail()
+	}
+	if c := NewArrayB([]bool{false, true, false}, 3).CumMax(0); c.At(0) || !c.At(1) || !c.At(2) {
+		t.Log("Bool CumMax failed", c)
+		t.Fail()
+	}
+
+	for i, v := range []struct {
+		a   interface{ GetErr() error }
+		err error
+	}{
+		{Arange(6).Reshape(2, 3).CumSum(2), IndexError},
+		{Arange(6).Reshape(2, 3).CumMax(-1), IndexError},
+		{Arange(6).Reshape(4).CumProd(0), ReshapeError},
+		{NewArrayB([]bool{true}, 1).CumSum(0), TypeError},
+		{NewArrayB([]bool{true}, 1).NaNCumSum(0), TypeError},
+		{(*Array64)(nil).CumMin(0), NilError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	a := NewArray64([]float64{1, 2, 4, 7, 0, 2, 8, 8}, 2, 4)
+	for i, v := range []struct {
+		a, res *Array64
+	}{
+		{NewArray64([]float64{1, 2, 4, 7, 0}).Diff(1, 0), NewArray64([]float64{1, 2, 3, -7})},
+		{NewArray64([]float64{1, 2, 4, 7, 0}).Diff(2, 0), NewArray64([]float64{1, 1, -10})},
+		{a.C().Diff(1, 1), NewArray64([]float64{1, 2, 3, 2, 6, 0}, 2, 3)},
+		{a.C().Diff(3, 1), NewArray64([]float64{0, -10}, 2, 1)},
+		{a.C().Diff(1, 0), NewArray64([]float64{-1, 0, 4, 1}, 1, 4)},
+		{a.C().Diff(0, 0), a},
+		{a.C().T().Diff(1, 0), NewArray64([]float64{1, 2, 2, 6, 3, 0}, 3, 2)},
+		{a.C().Diff(2, 0), NewArray64(nil, 0, 4)},
+	} {
+		if e := v.a.GetErr(); e != nil {
+			t.Log("Test", i, "Unexpected error", e)
+			t.Fail()
+			continue
+		}
+		if !sameNaN(v.a, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Received", v.a)
+			t.Fail()
+		}
+	}
+
+	if c := NewArray[uint8]([]uint8{5, 3}, 2).Diff(1, 0); c.At(0) != 254 {
+		t.Log("Uint Diff failed", c)
+		t.Fail()
+	}
+	for i, v := range []struct {
+		a   *Array64
+		err error
+	}{
+		{a.C().Diff(3, 0), ShapeError},
+		{a.C().Diff(-1, 0), ShapeError},
+		{a.C().Diff(1, 2), IndexError},
+		{a.C().Reshape(3).Diff(1, 0), ReshapeError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
+
+func BenchmarkCumSumInner(b *testing.B) {
+	a := RandArray64(0, 1, 100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.CumSum(0)
+	}
+}
@@ -1,9 +1,20 @@
 /*
 Package numgo provides implementations of n-dimensional array objects and operations.
 
-Two types of numgo arrays are currently supported:
+Arrays are generic over their element type, Array[T].  The supported element types are
+float32, float64, int8 to int64, uint8 to uint64, complex64, complex128 and bool.
+The most common types have aliases:
 Array64 holds float64 values
 Arrayb holds boolean values
+Array32, ArrayC64, ArrayC128, ArrayI8 ... ArrayI64 and ArrayU8 ... ArrayU64 hold the other types
+
+NewArray and Full create arrays of any element type, which is inferred from the arguments.
+
+ ints := numgo.NewArray([]int16{1, 2, 3, 4}, 2, 2)  // 2x2 ArrayI16
+ cplx := numgo.Full(1+2i, 3)                       // ArrayC128 of three 1+2i values
+
+Index results, such as ArgMax and Argsort, and counts are always returned as Array64.
+Integer division by zero results in zero, and arithmetic on bool arrays generates a TypeError.
 
 
 Basic usage
@@ -23,6 +34,21 @@ Array64 objects can be created from a slice or as an empty array.  Arange and Id
  arange.Sum()                          // An empty call operates on all data (Grand Total)
 
 
+Views
+
+SubArr returns a view of the source array, which shares the underlying data instead of copying it.  Changes made through the view are seen in the source array, and vice versa.  Views can be non-contiguous, and all methods handle them transparently.  C() materializes a view into an independent, contiguous copy.
+
+ arr := numgo.Arange(24).Reshape(2, 3, 4)
+ sub := arr.SubArr(1)                  // 3x4 view of the second half of arr
+ sub.AddC(100)                         // arr values 12-23 are now 112-123
+ cp := arr.SubArr(0).C()               // Independent copy, changes won't affect arr
+
+Slice selects ranges, steps and single indices along each axis, like numpy's slice notation, and also returns a view.
+
+ rev := arr.Slice(numgo.Idx(0), numgo.All, numgo.R(numgo.None, numgo.None, -1))  // numpy: arr[0, :, ::-1]
+ arr.SetSlice(numgo.NewArray64([]float64{1, 2}), numgo.Ellipsis, numgo.R(1, 3))  // numpy: arr[..., 1:3] = [1, 2]
+
+
 Fold and Map operations
 
 Map takes a function of type MapFunc and applies it across all data elements.  Fold and FoldCC take a function of type FoldFunc and applies it in contracting the data across one or more axes.
@@ -73,6 +99,17 @@ numgo is designed with function-chaining at its core, to allow different actions
  // ng.GetErr() will always return nil here,
  // so avoid stacking this type of error handling
 
+Linear algebra
+
+The linalg subpackage solves systems of equations, inverts matrices and calculates determinants,
+least squares solutions and matrix ranks.  It also provides the LU, QR, Cholesky, SVD and eigenvalue
+decompositions.  Arrays with more than two axes are treated as stacks of matrices.
+
+ a := numgo.NewArray64([]float64{3, 1, 1, 2}, 2, 2)
+ x := linalg.Solve(a, numgo.NewArray64([]float64{9, 8}))  // [2 3]
+ inv := linalg.Inv(a)                                      // Singular matrices generate a SingularMatrix error
+
+
 Debugging options
 
 Debugging can be enabled by calling numgo.Debug(true). This will give detailed error strings by using GetDebug() instead of GetErr(). This makes debugging chained method calls much easier.
@@ -0,0 +1,575 @@
+package numgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"runtime"
+
+	"github.com/Kunde21/numgo/internal"
+)
+
+// Integer is the set of integer element types supported by Array.
+type Integer interface {
+	int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64
+}
+
+// Float is the set of floating point element types supported by Array.
+type Float interface {
+	float32 | float64
+}
+
+// Complex is the set of complex element types supported by Array.
+type Complex interface {
+	complex64 | complex128
+}
+
+// Numeric is the set of element types that support arithmetic.
+type Numeric interface {
+	Integer | Float | Complex
+}
+
+// Elem is the set of all element types supported by Array.
+type Elem interface {
+	Numeric | bool
+}
+
+// kernels holds the operations that depend on the element type of an array.
+// The generic methods use them, so each type gets its fastest implementation
+// and float64 keeps the vectorized asm kernels.
+//
+// The slice operations follow the asm package: b is repeated when it's shorter than a.
+// Arithmetic kernels are nil for types that don't support them.
+type kernels[T Elem] struct {
+	name string
+
+	addC, subtrC, multC, divC, powC func(c T, d []T)
+	add, subtr, mult, div, pow      func(a, b []T)
+	vadd                            func(a, b []T)
+	hadd                            func(st uint64, a []T)
+	cumsum                          func(d []T)
+	fma12, fma21                    func(x T, a, b []T)
+	dot                             func(a, b []T) T
+	sum                             func(d []T) T
+	plus, times                     func(x, y T) T
+
+	// Element-wise math functions, applied in place.
+	exp, log, log1p, expm1, sqrt, abs func(d []T)
+	sin, cos, tan, tanh, sigmoid      func(d []T)
+	floor, ceil, round, sign          func(d []T)
+	clip                              func(lo, hi T, d []T)
+
+	// lt is the '<' comparison.  NaN values compare false, complex values
+	// are ordered by real then imaginary part, and false is less than true.
+	lt func(x, y T) bool
+
+	// nan is the missing value, which is NaN for floating point types and zero otherwise.
+	nan       T
+	fromFloat func(float64) T
+	toFloat   func(T) float64
+
+	// load and store convert elements for AsType.  store reports whether the value is represented exactly.
+	load  func(T) scalar
+	store func(scalar, Rounding) (T, bool)
+
+	encode func(d []T) (data interface{}, inf, nan []int64)
+	decode func(data json.RawMessage, inf, nan []int64) ([]T, error)
+}
+
+var (
+	kInt8       = intKernels[int8]("int8")
+	kInt16      = intKernels[int16]("int16")
+	kInt32      = intKernels[int32]("int32")
+	kInt64      = intKernels[int64]("int64")
+	kUint8      = intKernels[uint8]("uint8")
+	kUint16     = intKernels[uint16]("uint16")
+	kUint32     = intKernels[uint32]("uint32")
+	kUint64     = intKernels[uint64]("uint64")
+	kFloat32    = floatKernels[float32]("float32")
+	kFloat64    = asmKernels()
+	kComplex64  = complexKernels[complex64]("complex64")
+	kComplex128 = complexKernels[complex128]("complex128")
+	kBool       = boolKernels()
+)
+
+// kern returns the kernels for element type T.
+func kern[T Elem]() *kernels[T] {
+	var k interface{}
+	switch any(*new(T)).(type) {
+	case float64:
+		k = kFloat64
+	case float32:
+		k = kFloat32
+	case bool:
+		k = kBool
+	case int8:
+		k = kInt8
+	case int16:
+		k = kInt16
+	case int32:
+		k = kInt32
+	case int64:
+		k = kInt64
+	case uint8:
+		k = kUint8
+	case uint16:
+		k = kUint16
+	case uint32:
+		k = kUint32
+	case uint64:
+		k = kUint64
+	case complex64:
+		k = kComplex64
+	case complex128:
+		k = kComplex128
+	}
+	return k.(*kernels[T])
+}
+
+// numKernels creates the arithmetic kernels shared by all numeric types.
+func numKernels[T Numeric](name string) *kernels[T] {
+	return &kernels[T]{
+		name: name,
+		addC: func(c T, d []T) {
+			for i := range d {
+				d[i] += c
+			}
+		},
+		subtrC: func(c T, d []T) {
+			for i := range d {
+				d[i] -= c
+			}
+		},
+		multC: func(c T, d []T) {
+			for i := range d {
+				d[i] *= c
+			}
+		},
+		divC: func(c T, d []T) {
+			for i := range d {
+				d[i] /= c
+			}
+		},
+		add: func(a, b []T) {
+			for i, j := 0, 0; i < len(a); i, j = i+1, j+1 {
+				if j >= len(b) {
+					j = 0
+				}
+				a[i] += b[j]
+			}
+		},
+		subtr: func(a, b []T) {
+			for i, j := 0, 0; i < len(a); i, j = i+1, j+1 {
+				if j >= len(b) {
+					j = 0
+				}
+				a[i] -= b[j]
+			}
+		},
+		mult: func(a, b []T) {
+			for i, j := 0, 0; i < len(a); i, j = i+1, j+1 {
+				if j >= len(b) {
+					j = 0
+				}
+				a[i] *= b[j]
+			}
+		},
+		div: func(a, b []T) {
+			for i, j := 0, 0; i < len(a); i, j = i+1, j+1 {
+				if j >= len(b) {
+					j = 0
+				}
+				a[i] /= b[j]
+			}
+		},
+		vadd: func(a, b []T) {
+			for i := range a {
+				a[i] += b[i]
+			}
+		},
+		cumsum: scanSum[T],
+		hadd: func(st uint64, a []T) {
+			ln := uint64(len(a))
+			for k := uint64(0); k < ln/st; k++ {
+				a[k] = a[k*st]
+				for i := uint64(1); i < st; i++ {
+					a[k] += a[k*st+i]
+				}
+			}
+		},
+		fma12: func(x T, a, b []T) {
+			for i, j := 0, 0; i < len(a); i, j = i+1, j+1 {
+				if j >= len(b) {
+					j = 0
+				}
+				a[i] = x*a[i] + b[j]
+			}
+		},
+		fma21: func(x T, a, b []T) {
+			for i, j := 0, 0; i < len(a); i, j = i+1, j+1 {
+				if j >= len(b) {
+					j = 0
+				}
+				a[i] = a[i]*b[j] + x
+			}
+		},
+		dot: func(a, b []T) (r T) {
+			for i := range a {
+				r += a[i] * b[i]
+			}
+			return r
+		},
+		sum: func(d []T) (r T) {
+			for _, v := range d {
+				r += v
+			}
+			return r
+		},
+		plus:  func(x, y T) T { return x + y },
+		times: func(x, y T) T { return x * y },
+	}
+}
+
+// withPow sets the power kernels from a scalar power function.
+func (k *kernels[T]) withPow(p func(x, y T) T) *kernels[T] {
+	k.pow = func(a, b []T) {
+		for i, j := 0, 0; i < len(a); i, j = i+1, j+1 {
+			if j >= len(b) {
+				j = 0
+			}
+			a[i] = p(a[i], b[j])
+		}
+	}
+	k.powC = func(c T, d []T) {
+		for i := range d {
+			d[i] = p(d[i], c)
+		}
+	}
+	return k
+}
+
+// each creates an in-place kernel from a scalar function.
+func each[T Elem](f func(T) T) func(d []T) {
+	return func(d []T) {
+		for i, v := range d {
+			d[i] = f(v)
+		}
+	}
+}
+
+// withClip sets the clip kernel from the lt comparison, which leaves NaN values unchanged.
+func (k *kernels[T]) withClip() *kernels[T] {
+	k.clip = func(lo, hi T, d []T) {
+		for i, v := range d {
+			if k.lt(v, lo) {
+				v = lo
+			}
+			if k.lt(hi, v) {
+				v = hi
+			}
+			d[i] = v
+		}
+	}
+	return k
+}
+
+// intKernels creates the kernels for an integer type.
+// Integer division by zero results in zero, instead of a panic.
+func intKernels[T Integer](name string) *kernels[T] {
+	k := numKernels[T](name)
+	k.divC = func(c T, d []T) {
+		for i := range d {
+			if c == 0 {
+				d[i] = 0
+				continue
+			}
+			d[i] /= c
+		}
+	}
+	k.div = func(a, b []T) {
+		for i, j := 0, 0; i < len(a); i, j = i+1, j+1 {
+			if j >= len(b) {
+				j = 0
+			}
+			if b[j] == 0 {
+				a[i] = 0
+				continue
+			}
+			a[i] /= b[j]
+		}
+	}
+	k.lt = func(x, y T) bool { return x < y }
+	k.fromFloat = func(f float64) T { return T(f) }
+	k.toFloat = func(v T) float64 { return float64(v) }
+	k.load, k.store = intCast[T]()
+
+	// Integers are already rounded.  Transcendental functions need a floating point type.
+	k.floor, k.ceil, k.round = func([]T) {}, func([]T) {}, func([]T) {}
+	k.abs = each(func(x T) T {
+		if x < 0 {
+			return -x
+		}
+		return x
+	})
+	k.sign = each(func(x T) T {
+		switch {
+		case x < 0:
+			return T(0) - 1
+		case x > 0:
+			return 1
+		}
+		return 0
+	})
+	k.withClip()
+	k.encode = func(d []T) (interface{}, []int64, []int64) {
+		// []uint8 would be encoded as a base64 string.
+		if b, ok := any(d).([]uint8); ok {
+			u := make([]uint16, len(b))
+			for i, v := range b {
+				u[i] = uint16(v)
+			}
+			return u, nil, nil
+		}
+		return d, nil, nil
+	}
+	k.decode = func(data json.RawMessage, inf, nan []int64) (d []T, err error) {
+		err = json.Unmarshal(data, &d)
+		return d, err
+	}
+
+	// Negative powers truncate towards zero, as integer division does.
+	return k.withPow(func(x, y T) T {
+		if y < 0 {
+			switch {
+			case x == 1:
+				return 1
+			case x+1 == 0:
+				if y%2 == 0 {
+					return 1
+				}
+				return x
+			}
+			return 0
+		}
+		r := T(1)
+		for ; y > 0; y >>= 1 {
+			if y&1 == 1 {
+				r *= x
+			}
+			x *= x
+		}
+		return r
+	})
+}
+
+// floatKernels creates the kernels for a floating point type.
+func floatKernels[T Float](name string) *kernels[T] {
+	k := numKernels[T](name)
+	k.lt = func(x, y T) bool { return x < y }
+	k.nan = T(math.NaN())
+	k.fromFloat = func(f float64) T { return T(f) }
+	k.toFloat = func(v T) float64 { return float64(v) }
+	k.load, k.store = floatCast[T]()
+	k.withClip()
+
+	f := func(g func(float64) float64) func(d []T) {
+		return each(func(x T) T { return T(g(float64(x))) })
+	}
+	k.exp, k.log, k.log1p, k.expm1 = f(math.Exp), f(math.Log), f(math.Log1p), f(math.Expm1)
+	k.sqrt, k.abs = f(math.Sqrt), f(math.Abs)
+	k.sin, k.cos, k.tan, k.tanh = f(math.Sin), f(math.Cos), f(math.Tan), f(math.Tanh)
+	k.floor, k.ceil, k.round = f(math.Floor), f(math.Ceil), f(math.RoundToEven)
+	k.sigmoid = f(func(x float64) float64 {
+		// Only exponentiate negative values, so large inputs don't overflow.
+		if x >= 0 {
+			return 1 / (1 + math.Exp(-x))
+		}
+		e := math.Exp(x)
+		return e / (1 + e)
+	})
+	k.sign = f(func(x float64) float64 {
+		switch {
+		case x < 0:
+			return -1
+		case x > 0:
+			return 1
+		}
+		return x
+	})
+
+	k.encode = func(d []T) (interface{}, []int64, []int64) {
+		f := make([]float64, len(d))
+		for i, v := range d {
+			f[i] = float64(v)
+		}
+		inf, nan := encodeFloats(f)
+		return f, inf, nan
+	}
+	k.decode = func(data json.RawMessage, inf, nan []int64) ([]T, error) {
+		var f []float64
+		if err := json.Unmarshal(data, &f); err != nil || f == nil {
+			return nil, err
+		}
+		if err := decodeFloats(f, inf, nan); err != nil {
+			return nil, err
+		}
+		d := make([]T, len(f))
+		for i, v := range f {
+			d[i] = T(v)
+		}
+		return d, nil
+	}
+	return k.withPow(func(x, y T) T { return T(math.Pow(float64(x), float64(y))) })
+}
+
+// asmKernels creates the float64 kernels, using the vectorized asm implementations.
+func asmKernels() *kernels[float64] {
+	k := floatKernels[float64]("float64")
+	k.addC, k.subtrC, k.multC, k.divC = asm.AddC, asm.SubtrC, asm.MultC, asm.DivC
+	k.add, k.subtr, k.mult, k.div = asm.Add, asm.Subtr, asm.Mult, asm.Div
+	k.vadd, k.hadd = asm.Vadd, asm.Hadd
+	k.fma12, k.fma21 = asm.Fma12, asm.Fma21
+	k.dot = asm.DotProd
+	k.sqrt, k.abs, k.clip = asm.Sqrt, asm.Abs, asm.Clip
+	return k
+}
+
+// complexKernels creates the kernels for a complex type.
+// Complex values are encoded in JSON as interleaved real and imaginary parts.
+func complexKernels[T Complex](name string) *kernels[T] {
+	k := numKernels[T](name)
+	k.lt = func(x, y T) bool {
+		c, d := complex128(x), complex128(y)
+		return real(c) < real(d) || real(c) == real(d) && imag(c) < imag(d)
+	}
+	k.nan = T(complex(math.NaN(), math.NaN()))
+	k.fromFloat = func(f float64) T { return T(complex(f, 0)) }
+	k.toFloat = func(v T) float64 { return real(complex128(v)) }
+	k.load, k.store = complexCast[T]()
+	k.withClip()
+
+	// Complex values don't have rounding, and there are no accurate log1p and expm1 functions.
+	f := func(g func(complex128) complex128) func(d []T) {
+		return each(func(x T) T { return T(g(complex128(x))) })
+	}
+	k.exp, k.log, k.sqrt = f(cmplx.Exp), f(cmplx.Log), f(cmplx.Sqrt)
+	k.sin, k.cos, k.tan, k.tanh = f(cmplx.Sin), f(cmplx.Cos), f(cmplx.Tan), f(cmplx.Tanh)
+	k.sigmoid = f(func(x complex128) complex128 { return 1 / (1 + cmplx.Exp(-x)) })
+	k.abs = f(func(x complex128) complex128 { return complex(cmplx.Abs(x), 0) })
+	k.sign = f(func(x complex128) complex128 {
+		if x == 0 {
+			return x
+		}
+		return x / complex(cmplx.Abs(x), 0)
+	})
+
+	k.encode = func(d []T) (interface{}, []int64, []int64) {
+		f := make([]float64, 2*len(d))
+		for i, v := range d {
+			c := complex128(v)
+			f[2*i], f[2*i+1] = real(c), imag(c)
+		}
+		inf, nan := encodeFloats(f)
+		return f, inf, nan
+	}
+	k.decode = func(data json.RawMessage, inf, nan []int64) ([]T, error) {
+		var f []float64
+		if err := json.Unmarshal(data, &f); err != nil || f == nil {
+			return nil, err
+		}
+		if len(f)%2 != 0 {
+			return nil, fmt.Errorf("numgo: odd number of values in %s data", name)
+		}
+		if err := decodeFloats(f, inf, nan); err != nil {
+			return nil, err
+		}
+		d := make([]T, len(f)/2)
+		for i := range d {
+			d[i] = T(complex(f[2*i], f[2*i+1]))
+		}
+		return d, nil
+	}
+	return k.withPow(func(x, y T) T { return T(cmplx.Pow(complex128(x), complex128(y))) })
+}
+
+// boolKernels creates the kernels for bool arrays, which don't support arithmetic.
+func boolKernels() *kernels[bool] {
+	load, store := boolCast()
+	return &kernels[bool]{
+		load:      load,
+		store:     store,
+		name:      "bool",
+		lt:        func(x, y bool) bool { return !x && y },
+		fromFloat: func(f float64) bool { return f != 0 },
+		toFloat: func(v bool) float64 {
+			if v {
+				return 1
+			}
+			return 0
+		},
+		encode: func(d []bool) (interface{}, []int64, []int64) { return d, nil, nil },
+		decode: func(data json.RawMessage, inf, nan []int64) (d []bool, err error) {
+			err = json.Unmarshal(data, &d)
+			return d, err
+		},
+	}
+}
+
+// encodeFloats replaces the values of d that aren't JSON defined with zero, and returns their positions.
+// Positions are one-based, and negative for negative infinity.
+func encodeFloats(d []float64) (inf, nan []int64) {
+	for k, v := range d {
+		switch {
+		case math.IsNaN(v):
+			d[k] = 0
+			nan = append(nan, int64(k+1))
+		case math.IsInf(v, 1):
+			d[k] = 0
+			inf = append(inf, int64(k+1))
+		case math.IsInf(v, -1):
+			d[k] = 0
+			inf = append(inf, int64(-(k + 1)))
+		}
+	}
+	return inf, nan
+}
+
+// decodeFloats restores the values recorded by encodeFloats.
+func decodeFloats(d []float64, inf, nan []int64) error {
+	for _, v := range nan {
+		if v < 1 || v > int64(len(d)) {
+			return fmt.Errorf("numgo: NaN position %d out of range", v)
+		}
+		d[v-1] = math.NaN()
+	}
+	for _, v := range inf {
+		switch {
+		case v >= 1 && v <= int64(len(d)):
+			d[v-1] = math.Inf(1)
+		case v <= -1 && -v <= int64(len(d)):
+			d[-v-1] = math.Inf(-1)
+		default:
+			return fmt.Errorf("numgo: Inf position %d out of range", v)
+		}
+	}
+	return nil
+}
+
+// isReal reports whether the element type is a real number type, which can be ordered and binned.
+func (k *kernels[T]) isReal() bool {
+	return k.sum != nil && k.load(*new(T)).kind != 'c'
+}
+
+// valOp checks that the element type of the array supports an operation, generating a TypeError if not.
+func (a *Array[T]) valOp(supported bool, mthd string) bool {
+	if supported {
+		return false
+	}
+	a.err = TypeError
+	if debug {
+		a.debug = fmt.Sprintf("%s() is not supported on %s arrays.", mthd, kern[T]().name)
+		a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+	}
+	return true
+}
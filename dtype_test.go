@@ -0,0 +1,191 @@
+package numgo
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func init() {
+	debug = true
+}
+
+func TestIntArith(t *testing.T) {
+	a := NewArray([]int16{1, 2, 3, 4, 5, 6}, 2, 3)
+	for i, v := range []struct {
+		a   *ArrayI16
+		res []int16
+	}{
+		{a.C().Add(NewArray([]int16{10, 20, 30})), []int16{11, 22, 33, 14, 25, 36}},
+		{a.C().Subtr(NewArray([]int16{1, 1}, 2, 1)), []int16{0, 1, 2, 3, 4, 5}},
+		{a.C().MultC(3), []int16{3, 6, 9, 12, 15, 18}},
+		{a.C().DivC(2), []int16{0, 1, 1, 2, 2, 3}},
+		{a.C().DivC(0), []int16{0, 0, 0, 0, 0, 0}},
+		{a.C().Div(NewArray([]int16{2, 0, 3})), []int16{0, 0, 1, 2, 0, 2}},
+		{a.C().PowC(2), []int16{1, 4, 9, 16, 25, 36}},
+		{a.C().PowC(-1), []int16{1, 0, 0, 0, 0, 0}},
+		{a.C().FMA12(2, NewArray([]int16{1})), []int16{3, 5, 7, 9, 11, 13}},
+		{a.C().Sum(0), []int16{5, 7, 9}},
+		{a.C().Sum(), []int16{21}},
+		{a.C().Max(1), []int16{3, 6}},
+		{a.C().Mean(1), []int16{2, 5}},
+		{a.MatProd(NewArray([]int16{1, 0, 1}, 3, 1)), []int16{4, 10}},
+	} {
+		if e := v.a.GetErr(); e != nil {
+			t.Log("Test", i, "Unexpected error", e)
+			t.Fail()
+			continue
+		}
+		if !v.a.Equals(NewArray(v.res, v.a.shape...)).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Got", v.a)
+			t.Fail()
+		}
+	}
+
+	u := NewArray([]uint8{250, 3})
+	if r := u.AddC(10); r.At(0) != 4 || r.At(1) != 13 {
+		t.Log("Expected uint8 wrap around [4 13] Got", r)
+		t.Fail()
+	}
+}
+
+func TestFloat32(t *testing.T) {
+	a := NewArray([]float32{3, float32(math.NaN()), 1, 2}, 2, 2)
+	if r := a.C().Sort(1); !r.Equals(NewArray([]float32{3, float32(math.NaN()), 1, 2}, 2, 2)).All().At(0) {
+		t.Log("Sort Expected [[3 NaN] [1 2]] Got", r)
+		t.Fail()
+	}
+	if r := a.C().NaNSum(0); r.At(0) != 4 || r.At(1) != 2 {
+		t.Log("NaNSum Expected [4 2] Got", r)
+		t.Fail()
+	}
+	if r := a.ArgMin(1); !r.Equals(NewArray64([]float64{1, 0})).All().At(0) {
+		t.Log("ArgMin Expected [1 0] Got", r)
+		t.Fail()
+	}
+	if r := a.NaNCount(); r.At(0) != 3 {
+		t.Log("NaNCount Expected 3 Got", r)
+		t.Fail()
+	}
+	if r := a.T(); r.At(0, 1) != 1 || r.At(1, 1) != 2 {
+		t.Log("T Expected [[3 1] [NaN 2]] Got", r)
+		t.Fail()
+	}
+}
+
+func TestComplex(t *testing.T) {
+	a := NewArray([]complex128{1 + 1i, 2, 3i})
+	if r := a.C().Mult(NewArray([]complex128{1i})); !r.Equals(NewArray([]complex128{-1 + 1i, 2i, -3})).All().At(0) {
+		t.Log("Mult Expected [-1+1i 2i -3] Got", r)
+		t.Fail()
+	}
+	if r := a.C().Sum(); r.At(0) != 3+4i {
+		t.Log("Sum Expected 3+4i Got", r)
+		t.Fail()
+	}
+	if r := a.MatProd(a); r.At(0) != -5+2i {
+		t.Log("MatProd Expected -5+2i Got", r)
+		t.Fail()
+	}
+	if r := a.C().Sort(0); !r.Equals(NewArray([]complex128{3i, 1 + 1i, 2})).All().At(0) {
+		t.Log("Sort Expected [3i 1+1i 2] Got", r)
+		t.Fail()
+	}
+	if r := Einsum("i,i->", a, NewArray([]complex128{1, 1, 1})); r.At(0) != 3+4i {
+		t.Log("Einsum Expected 3+4i Got", r)
+		t.Fail()
+	}
+}
+
+func TestBoolType(t *testing.T) {
+	a := Fullb(true, 2, 2)
+	for i, v := range []struct {
+		a   *Arrayb
+		err error
+	}{
+		{a.C().Add(a), TypeError},
+		{a.C().AddC(true), TypeError},
+		{a.C().Sum(), TypeError},
+		{a.C().NaNSum(0), TypeError},
+		{a.C().MatProd(a), TypeError},
+		{a.C().Tensordot(a, nil, nil), TypeError},
+		{a.C().Max(0), nil},
+		{a.C().Sort(0), nil},
+		{a.C().Reshape(4), nil},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+		}
+	}
+
+	if r := a.Nonzero(); r.At(0) != 4 {
+		t.Log("Nonzero Expected 4 Got", r)
+		t.Fail()
+	}
+	if r := NewArray([]int32{0, 2, 0}).Any(); !r.At(0) {
+		t.Log("Any Expected true Got", r)
+		t.Fail()
+	}
+	if r := NewArray([]int32{0, 2, 0}).All(); r.At(0) {
+		t.Log("All Expected false Got", r)
+		t.Fail()
+	}
+}
+
+func TestJSONTypes(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		v    interface{}
+		tmp  interface{}
+		data string
+	}{
+		{NewArray([]int32{-1, 2, 3}), new(ArrayI32), `[-1,2,3]`},
+		{NewArray([]uint8{1, 255}), new(ArrayU8), `[1,255]`},
+		{NewArray([]float32{1.5, float32(math.Inf(-1))}), new(Array32), `[1.5,0]`},
+		{NewArray([]complex128{complex(1, nan), 2i}), new(ArrayC128), `[1,0,0,2]`},
+	}
+	for i, v := range tests {
+		b, err := json.Marshal(v.v)
+		if err != nil {
+			t.Error("Marshal Error in test", i, ":", err)
+			continue
+		}
+		var s struct{ Data json.RawMessage }
+		if json.Unmarshal(b, &s); string(s.Data) != v.data {
+			t.Log("Test", i, "Expected data", v.data, "Got", string(s.Data))
+			t.Fail()
+		}
+		if err = json.Unmarshal(b, v.tmp); err != nil {
+			t.Error("Unmarshal Error in test", i, ":", err)
+			continue
+		}
+
+		var eq *Arrayb
+		switch a := v.v.(type) {
+		case *ArrayI32:
+			eq = a.Equals(v.tmp.(*ArrayI32))
+		case *ArrayU8:
+			eq = a.Equals(v.tmp.(*ArrayU8))
+		case *Array32:
+			eq = a.Equals(v.tmp.(*Array32))
+		case *ArrayC128:
+			eq = v.tmp.(*ArrayC128).Map(func(c complex128) complex128 {
+				if c != c {
+					return 99
+				}
+				return c
+			}).Equals(NewArray([]complex128{99, 2i}))
+		}
+		if !eq.All().At(0) {
+			t.Log("Value changed in test", i)
+			t.Log(string(b))
+			t.Error(v.tmp)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`{"shape":[1],"data":[1,2,3]}`), new(ArrayC64)); err == nil {
+		t.Log("Expected error for odd complex data length")
+		t.Fail()
+	}
+}
@@ -31,6 +31,22 @@ var (
 	// This will store the panic message in the debug string
 	// when debugging is turned off, for proper errror reporting
 	FoldMapError = &ngError{"FoldMapError: Fold/Map function panic encountered."}
+	// TypeError flags operations that aren't supported by the element type of an array,
+	// such as arithmetic on bool arrays.
+	TypeError = &ngError{"TypeError: Operation not supported by the array element type."}
+	// CastError flags values that can't be represented in the new element type of a checked AsType() conversion.
+	CastError = &ngError{"CastError: Value can not be represented in the new element type."}
+	// SingularMatrix flags linear algebra operations that received a singular matrix,
+	// such as solving a system or inverting a matrix that has no inverse.
+	SingularMatrix = &ngError{"SingularMatrix: Matrix is singular."}
+	// NotPositiveDefinite flags matrices received by a Cholesky factorization that aren't positive definite.
+	NotPositiveDefinite = &ngError{"NotPositiveDefinite: Matrix is not positive definite."}
+	// NoConvergence flags iterative algorithms, such as SVD and eigenvalue calculations,
+	// that didn't converge within their iteration limit.
+	NoConvergence = &ngError{"NoConvergence: Calculation did not converge."}
+	// FormatError flags data that can't be decoded into an array, such as a malformed
+	// or truncated .npy file, or a stored element type that isn't supported.
+	FormatError = &ngError{"FormatError: Data is not in a supported format."}
 
 	debug    bool
 	stackBuf []byte
@@ -53,13 +69,13 @@ func Debug(set bool) bool {
 	return debug
 }
 
-// HasErr tests for the existence of an error on the Array64 object.
+// HasErr tests for the existence of an error on the Array object.
 //
 // Errors will be maintained through a chain of function calls,
 // so only the first error will be returned when GetErr() is called.
 // Use HasErr() as a gate for the GetErr() or GetDebug() choice in
 // error handling code.
-func (a *Array64) HasErr() bool {
+func (a *Array[T]) HasErr() bool {
 	if a == nil || (a.data == nil && a.err == nil) {
 		return true
 	}
@@ -71,7 +87,7 @@ func (a *Array64) HasErr() bool {
 // This will only return an error value once per error instance.  Do not use
 // it in the if statement to test for the existence of an error.  HasErr() is
 // provided for that purpose.
-func (a *Array64) GetErr() (err error) {
+func (a *Array[T]) GetErr() (err error) {
 	if a == nil || (a.data == nil && a.err == nil) {
 		return NilError
 	}
@@ -80,19 +96,44 @@ func (a *Array64) GetErr() (err error) {
 	return
 }
 
-func (a *Array64) getErr() error {
+func (a *Array[T]) getErr() error {
 	if a == nil || (a.data == nil && a.err == nil) {
 		return NilError
 	}
 	return a.err
 }
 
+// errTo passes the error state of a on to the result of a method with a different element type.
+// a is returned unchanged when it already has element type U.
+func errTo[U, T Elem](a *Array[T]) *Array[U] {
+	if r, ok := any(a).(*Array[U]); ok {
+		return r
+	}
+	r := &Array[U]{err: a.getErr()}
+	if a != nil {
+		r.debug, r.stack = a.debug, a.stack
+	}
+	return r
+}
+
+// ErrArray creates an array holding err, for packages that extend numgo, such as linalg.
+// When debugging is enabled, the debug string is formatted from format and args,
+// and the stack trace is recorded at the point of the call.
+func ErrArray[T Elem](err error, format string, args ...interface{}) *Array[T] {
+	a := &Array[T]{err: err}
+	if debug {
+		a.debug = fmt.Sprintf(format, args...)
+		a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+	}
+	return a
+}
+
 // GetDebug returns and clears the error object from the array object.  The returned debug string
 // will include the function that generated the error and the arguments that caused it.
 //
 // This debug information will only be generated and returned if numgo.Debug is set to true
 // before the function call that causes the error.
-func (a *Array64) GetDebug() (err error, debugStr, stackTrace string) {
+func (a *Array[T]) GetDebug() (err error, debugStr, stackTrace string) {
 	if a == nil || (a.data == nil && a.err == nil) {
 		err = NilError
 		if debug {
@@ -128,6 +169,18 @@ func encodeErr(err error) int8 {
 		return 6
 	case FoldMapError:
 		return 7
+	case TypeError:
+		return 8
+	case CastError:
+		return 9
+	case SingularMatrix:
+		return 10
+	case NotPositiveDefinite:
+		return 11
+	case NoConvergence:
+		return 12
+	case FormatError:
+		return 13
 	}
 	return -1
 }
@@ -151,62 +204,20 @@ func decodeErr(err int8) (a error) {
 		a = InvIndexError
 	case 7:
 		a = FoldMapError
+	case 8:
+		a = TypeError
+	case 9:
+		a = CastError
+	case 10:
+		a = SingularMatrix
+	case 11:
+		a = NotPositiveDefinite
+	case 12:
+		a = NoConvergence
+	case 13:
+		a = FormatError
 	default:
 		a = &ngError{fmt.Sprintf("Unknown error Unmarshaled: %d", err)}
 	}
 	return
 }
-
-// HasErr tests for the existence of an error on the Arrayb object.
-//
-// Errors will be maintained through a chain of function calls,
-// so only the first error will be returned when GetErr() is called.
-// Use HasErr() as a gate for the GetErr() or GetDebug() choice in
-// error handling code.
-func (a *Arrayb) HasErr() bool {
-	if a == nil || (a.data == nil && a.err == nil) {
-		return true
-	}
-	return a.err != nil
-}
-
-// GetErr returns the error object and clears the error from the array.
-//
-// This will only return an error value once per error instance.  Do not use
-// it in the if statement to test for the existence of an error.  HasErr() is
-// provided for that purpose.
-func (a *Arrayb) GetErr() (err error) {
-	if a == nil || (a.data == nil && a.err == nil) {
-		return NilError
-	}
-	err = a.err
-	a.err, a.debug = nil, ""
-	return
-}
-
-func (a *Arrayb) getErr() error {
-	if a == nil || (a.data == nil && a.err == nil) {
-		return NilError
-	}
-	return a.err
-}
-
-// GetDebug returns and clears the error object from the array object.  The returned debug string
-// will include the function that generated the error and the arguments that caused it.
-//
-// This debug information will only be generated and returned if numgo.Debug is set to true
-// before the function call that causes the error.
-func (a *Arrayb) GetDebug() (err error, debugStr, stackTrace string) {
-	if a == nil || (a.data == nil && a.err == nil) {
-		err = NilError
-		if debug {
-			debugStr = "Nil pointer received in GetDebug().  Source array was not initialized."
-			stackTrace = string(stackBuf[:runtime.Stack(stackBuf, false)])
-		}
-		return
-	}
-	err, debugStr, stackTrace = a.err, a.debug, a.stack
-	a.err, a.debug, a.stack = nil, "", ""
-
-	return
-}
\ No newline at end of file
@@ -39,6 +39,12 @@ func TestEncodeDecode(t *testing.T) {
 		InvIndexError,
 		InvIndexError,
 		FoldMapError,
+		TypeError,
+		CastError,
+		SingularMatrix,
+		NotPositiveDefinite,
+		NoConvergence,
+		FormatError,
 	} {
 		if e := decodeErr(encodeErr(v)); v != e {
 			t.Log("Failed:", v)
@@ -58,6 +64,22 @@ func TestEncodeDecode(t *testing.T) {
 	}
 }
 
+func TestErrArray(t *testing.T) {
+	a := ErrArray[float64](SingularMatrix, "Singular matrix received by %s().", "Solve")
+	if !a.HasErr() {
+		t.Log("ErrArray should hold its error")
+		t.Fail()
+	}
+	if a.shape != nil || a.strides != nil || a.data != nil {
+		t.Log("ErrArray should have no shape or data, Got", a.shape, a.strides, a.data)
+		t.Fail()
+	}
+	if err, debug, _ := a.GetDebug(); err != SingularMatrix || debug != "Singular matrix received by Solve()." {
+		t.Log("Expected SingularMatrix, Got", err, debug)
+		t.Fail()
+	}
+}
+
 func TestGetErr(t *testing.T) {
 	var a *Array64
 	var b *Arrayb
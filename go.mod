@@ -0,0 +1,3 @@
+module github.com/Kunde21/numgo
+
+go 1.21
@@ -0,0 +1,341 @@
+package numgo
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+)
+
+// Binning functions.
+//
+// Bins have equal widths over a range, and a zero range of [0, 0] uses the range of the data.
+// Each bin includes its lower edge, and the last bin also includes the upper edge of the range.
+// NaN values and values outside of the range aren't counted, the same way NaNCount ignores NaN values.
+
+// valBins checks the bin counts and ranges received by the histogram methods.
+func (a *Array[T]) valBins(bins []int, rng [][2]float64, mthd string) bool {
+	for i, b := range bins {
+		if b < 1 {
+			a.err = ShapeError
+			if debug {
+				a.debug = fmt.Sprintf("Bins received by %s() must be positive: %v", mthd, bins)
+				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return true
+		}
+		if i >= len(rng) {
+			continue
+		}
+		if lo, hi := rng[i][0], rng[i][1]; !(lo <= hi) || math.IsInf(lo, 0) || math.IsInf(hi, 0) {
+			a.err = ShapeError
+			if debug {
+				a.debug = fmt.Sprintf("Range received by %s() must be finite and increasing: %v", mthd, rng[i])
+				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// binEdges calculates the edges of bins equal width bins over rng.
+// A zero range uses the range of the finite values in v, and empty ranges are widened by 0.5 on each side.
+func binEdges(v []float64, rng [2]float64, bins int) []float64 {
+	lo, hi := rng[0], rng[1]
+	if lo == 0 && hi == 0 {
+		lo, hi = math.Inf(1), math.Inf(-1)
+		for _, x := range v {
+			if x == x && !math.IsInf(x, 0) {
+				lo, hi = math.Min(lo, x), math.Max(hi, x)
+			}
+		}
+		if lo > hi {
+			lo, hi = 0, 1
+		}
+	}
+	if lo == hi {
+		lo, hi = lo-0.5, hi+0.5
+	}
+
+	e := make([]float64, bins+1)
+	for i := range e {
+		e[i] = lo + (hi-lo)*float64(i)/float64(bins)
+	}
+	e[bins] = hi
+	return e
+}
+
+// binIndex finds the bin of x, or -1 if x is NaN or outside of the edges.
+func binIndex(e []float64, x float64) int {
+	bins, lo, hi := len(e)-1, e[0], e[len(e)-1]
+	if !(x >= lo && x <= hi) {
+		return -1
+	}
+
+	// Rounding in the division can be off by one bin, so the index is checked against the edges.
+	i := int((x - lo) / (hi - lo) * float64(bins))
+	switch {
+	case i >= bins:
+		i = bins - 1
+	case x < e[i]:
+		i--
+	case x >= e[i+1] && i+1 < bins:
+		i++
+	}
+	return i
+}
+
+// floats converts the elements to float64 values.
+func floats[T Elem](d []T) []float64 {
+	k, v := kern[T](), make([]float64, len(d))
+	for i, x := range d {
+		v[i] = k.toFloat(x)
+	}
+	return v
+}
+
+// Histogram counts the elements along the given axes into bins equal width bins over rng.
+// Empty call counts all elements, and returns counts with shape [bins].  Otherwise, counts has the
+// remaining axes followed by an axis of length bins, so each row is a histogram.
+//
+// All rows use the same bin edges, which are returned with shape [bins+1].
+// Complex arrays are not supported.
+func (a *Array[T]) Histogram(bins int, rng [2]float64, axis ...int) (counts, edges *Array64) {
+	if a.valAxis(&axis, "Histogram") || a.valOp(kern[T]().isReal(), "Histogram") ||
+		a.valBins([]int{bins}, [][2]float64{rng}, "Histogram") {
+		e := errTo[float64](a)
+		return e, e
+	}
+
+	d, sh, span := a.spans(axis)
+	v := floats(d)
+	if len(axis) == 0 {
+		sh = sh[:0]
+	}
+
+	e := binEdges(v, rng, bins)
+	counts = newArray64(append(sh, bins)...)
+	for i := 0; i < len(v); i += span {
+		c := counts.data[i/span*bins : (i/span+1)*bins]
+		for _, x := range v[i : i+span] {
+			if b := binIndex(e, x); b >= 0 {
+				c[b]++
+			}
+		}
+	}
+	return counts, NewArray64(e)
+}
+
+// HistogramDD counts the rows of an N×D array of samples into a D-dimensional histogram.
+// Each dimension has its own number of bins and range, and a nil or short rng uses the range of the data
+// for the remaining dimensions.  Samples with a NaN value in any dimension aren't counted.
+//
+// The counts have shape bins, and the edges of each dimension are returned in order.
+// Complex arrays are not supported.
+func (a *Array[T]) HistogramDD(bins []int, rng [][2]float64) (counts *Array64, edges []*Array64) {
+	switch {
+	case a.HasErr(), a.valOp(kern[T]().isReal(), "HistogramDD"), a.valBins(bins, rng, "HistogramDD"):
+		return errTo[float64](a), nil
+	case len(a.shape) != 2 || a.shape[1] != len(bins) || len(rng) > len(bins):
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Samples received by HistogramDD() must be N×%d.  Shape: %v  Ranges: %v", len(bins), a.shape, len(rng))
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a), nil
+	}
+
+	n, dims := a.shape[0], len(bins)
+	cols := floats(a.permute([]int{1, 0}).data)
+	e := make([][]float64, dims)
+	for j := range e {
+		var r [2]float64
+		if j < len(rng) {
+			r = rng[j]
+		}
+		e[j] = binEdges(cols[j*n:(j+1)*n], r, bins[j])
+		edges = append(edges, NewArray64(e[j]))
+	}
+
+	counts = newArray64(bins...)
+sample:
+	for i := 0; i < n; i++ {
+		off := 0
+		for j := range e {
+			b := binIndex(e[j], cols[j*n+i])
+			if b < 0 {
+				continue sample
+			}
+			off += b * counts.strides[j+1]
+		}
+		counts.data[off]++
+	}
+	return counts, edges
+}
+
+// Histogram2D counts the pairs of elements in a and b into a 2-D histogram, with a along the first axis.
+// The arrays must have the same shape, and all elements are counted.  This is the same as HistogramDD
+// with two dimensions.
+func (a *Array[T]) Histogram2D(b *Array[T], bins [2]int, rng [2][2]float64) (counts, aEdges, bEdges *Array64) {
+	switch {
+	case a.HasErr():
+		e := errTo[float64](a)
+		return e, e, e
+	case b == nil:
+		a.err = NilError
+		if debug {
+			a.debug = "Array received by Histogram2D() is a Nil pointer."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		e := errTo[float64](a)
+		return e, e, e
+	case b.HasErr():
+		a.err = b.getErr()
+		if debug {
+			a.debug = "Array received by Histogram2D() is in error."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		e := errTo[float64](a)
+		return e, e, e
+	case !equalShape(a.shape, b.shape):
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Arrays received by Histogram2D() do not match.  Shape: %v  Val shape: %v", a.shape, b.shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		e := errTo[float64](a)
+		return e, e, e
+	}
+
+	// Pair the elements as rows of an N×2 sample array.
+	s := newArray[T](a.strides[0], 2)
+	for i, v := range a.flat() {
+		s.data[2*i] = v
+	}
+	for i, v := range b.flat() {
+		s.data[2*i+1] = v
+	}
+
+	counts, edges := s.HistogramDD(bins[:], rng[:])
+	if s.HasErr() {
+		a.err, a.debug, a.stack = s.err, s.debug, s.stack
+		e := errTo[float64](a)
+		return e, e, e
+	}
+	return counts, edges[0], edges[1]
+}
+
+// Digitize finds the index of the bin that each element belongs to, where bins holds
+// monotonically increasing or decreasing bin edges.
+//
+// For increasing edges, index i satisfies bins[i-1] <= x < bins[i], and for decreasing edges,
+// bins[i-1] > x >= bins[i].  Values below or above all edges have the index 0 or len(bins).
+// NaN values have the index len(bins).  Complex arrays are not supported.
+func (a *Array[T]) Digitize(bins *Array64) *Array64 {
+	switch {
+	case a.HasErr(), a.valOp(kern[T]().isReal(), "Digitize"):
+		return errTo[float64](a)
+	case bins == nil:
+		a.err = NilError
+		if debug {
+			a.debug = "Bins received by Digitize() is a Nil pointer."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	case bins.HasErr():
+		a.err = bins.getErr()
+		if debug {
+			a.debug = "Bins received by Digitize() is in error."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	}
+
+	e := bins.flat()
+	inc, dec := true, true
+	for i := 1; i < len(e); i++ {
+		inc, dec = inc && e[i-1] <= e[i], dec && e[i-1] >= e[i]
+	}
+	if len(bins.shape) != 1 || !inc && !dec {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Bins received by Digitize() must be 1-D and monotonic: %v", bins)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	}
+
+	r := newArray64(a.shape...)
+	for i, x := range floats(a.flat()) {
+		switch {
+		case x != x:
+			r.data[i] = float64(len(e))
+		case inc:
+			r.data[i] = float64(sort.Search(len(e), func(j int) bool { return e[j] > x }))
+		default:
+			r.data[i] = float64(sort.Search(len(e), func(j int) bool { return e[j] <= x }))
+		}
+	}
+	return r
+}
+
+// Bincount counts the occurrences of each value in the array, where all elements are
+// non-negative integer values.  The result has a length of one more than the largest value.
+//
+// When weights is not nil, it must have the same shape as the array, and each occurrence
+// adds its weight instead of one.  Negative, fractional or NaN values generate an InvIndexError,
+// and values of math.MaxInt32 or more generate a ShapeError.
+func (a *Array[T]) Bincount(weights *Array64) *Array64 {
+	switch {
+	case a.HasErr(), a.valOp(kern[T]().isReal(), "Bincount"):
+		return errTo[float64](a)
+	case weights != nil && weights.HasErr():
+		a.err = weights.getErr()
+		if debug {
+			a.debug = "Weights received by Bincount() is in error."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	case weights != nil && !equalShape(a.shape, weights.shape):
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Weights received by Bincount() do not match.  Shape: %v  Weights shape: %v", a.shape, weights.shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	}
+
+	v, mx := floats(a.flat()), -1.0
+	for _, x := range v {
+		if !(x >= 0) || x != math.Trunc(x) || math.IsInf(x, 0) {
+			a.err = InvIndexError
+			if debug {
+				a.debug = fmt.Sprintf("Bincount() received a value that is not a non-negative integer: %v", x)
+				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return errTo[float64](a)
+		}
+		mx = math.Max(mx, x)
+	}
+	if mx >= math.MaxInt32 {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Bincount() received a value too large to count.  Max: %v  Limit: %d", mx, math.MaxInt32-1)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	}
+
+	r := newArray64(int(mx) + 1)
+	if weights == nil {
+		for _, x := range v {
+			r.data[int(x)]++
+		}
+		return r
+	}
+	for i, w := range weights.flat() {
+		r.data[int(v[i])] += w
+	}
+	return r
+}
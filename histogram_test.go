@@ -0,0 +1,199 @@
+package numgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+	nan := math.NaN()
+	a := NewArray64([]float64{1, 2, 2, 3, 3, 3, 4, 4, 4, 4}, 2, 5)
+	for i, v := range []struct {
+		counts, edges, c, e *Array64
+	}{
+		{c: NewArray64([]float64{1, 2, 3, 4}), e: NewArray64([]float64{1, 1.75, 2.5, 3.25, 4})},
+		{c: NewArray64([]float64{1, 5, 4}), e: NewArray64([]float64{0, 2, 4, 6})},
+		{c: NewArray64([]float64{1, 2, 2, 0, 0, 5}, 2, 3), e: NewArray64([]float64{1, 2, 3, 4})},
+		{c: NewArray64([]float64{1, 0, 1, 0, 1, 1, 0, 1, 1, 0, 0, 2, 0, 0, 2}, 5, 3), e: NewArray64([]float64{1, 2, 3, 4})},
+		{c: NewArray64([]float64{2, 1}), e: NewArray64([]float64{0, 0.5, 1})},
+		{c: NewArray64([]float64{2}), e: NewArray64([]float64{4.5, 5.5})},
+		{c: NewArray64([]float64{1, 0, 2}), e: NewArray64([]float64{0, 1, 2, 3})},
+	} {
+		switch i {
+		case 0:
+			v.counts, v.edges = a.Histogram(4, [2]float64{})
+		case 1:
+			v.counts, v.edges = a.Histogram(3, [2]float64{0, 6})
+		case 2:
+			v.counts, v.edges = a.Histogram(3, [2]float64{1, 4}, 1)
+		case 3:
+			v.counts, v.edges = a.Histogram(3, [2]float64{1, 4}, 0)
+		case 4:
+			v.counts, v.edges = NewArray64([]float64{0, nan, 0.2, 1, math.Inf(1)}).Histogram(2, [2]float64{})
+		case 5:
+			v.counts, v.edges = NewArray64([]float64{5, 5}).Histogram(1, [2]float64{})
+		case 6:
+			v.counts, v.edges = NewArray[int8]([]int8{-1, 0, 2, 3, 9}, 5).Histogram(3, [2]float64{0, 3})
+		}
+		if e := v.counts.GetErr(); e != nil {
+			t.Log("Test", i, "Unexpected error", e)
+			t.Fail()
+			continue
+		}
+		if !sameNaN(v.counts, v.c) || !sameNaN(v.edges, v.e) {
+			t.Log("Test", i, "Expected", v.c, v.e, "Received", v.counts, v.edges)
+			t.Fail()
+		}
+	}
+
+	// Bin indexes must match the returned edges, even when the division rounds.
+	x := RandArray64(-3, 7, 1000)
+	c, e := x.Histogram(7, [2]float64{-3, 4})
+	var n float64
+	for _, v := range c.Values() {
+		n += v
+	}
+	if n != x.Less(full(4.0, 1000)).Nonzero().At(0) {
+		t.Log("Expected", x.Less(full(4.0, 1000)).Nonzero(), "values Received", n)
+		t.Fail()
+	}
+	for b := 0; b < 7; b++ {
+		lo, hi := e.At(b), e.At(b+1)
+		cnt := 0.0
+		for _, v := range x.Values() {
+			if v >= lo && (v < hi || b == 6 && v == hi) {
+				cnt++
+			}
+		}
+		if cnt != c.At(b) {
+			t.Log("Bin", b, "Expected", cnt, "Received", c.At(b))
+			t.Fail()
+		}
+	}
+
+	for i, v := range []struct {
+		a   *Array64
+		err error
+	}{
+		{func() *Array64 { c, _ := a.C().Histogram(0, [2]float64{}); return c }(), ShapeError},
+		{func() *Array64 { c, _ := a.C().Histogram(2, [2]float64{3, 1}); return c }(), ShapeError},
+		{func() *Array64 { c, _ := a.C().Histogram(2, [2]float64{0, math.Inf(1)}); return c }(), ShapeError},
+		{func() *Array64 { c, _ := a.C().Histogram(2, [2]float64{}, 2); return c }(), IndexError},
+		{func() *Array64 { _, e := NewArrayB([]bool{true}, 1).Histogram(2, [2]float64{}); return e }(), TypeError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
+
+func TestHistogramDD(t *testing.T) {
+	t.Parallel()
+	x := NewArray64([]float64{0, 1, 1, 2, 3, 3, 0, 3})
+	y := NewArray64([]float64{0, 0, 1, 1, 2, 2, math.NaN(), 2})
+	c, xe, ye := x.Histogram2D(y, [2]int{2, 3}, [2][2]float64{{0, 4}})
+	if e := c.GetErr(); e != nil {
+		t.Log("Unexpected error", e)
+		t.FailNow()
+	}
+	if !sameNaN(c, NewArray64([]float64{2, 1, 0, 0, 1, 3}, 2, 3)) || !sameNaN(xe, NewArray64([]float64{0, 2, 4})) ||
+		!sameNaN(ye, NewArray64([]float64{0, 2.0 / 3, 4.0 / 3, 2})) {
+		t.Log("Histogram2D failed", c, xe, ye)
+		t.Fail()
+	}
+
+	s := NewArray64([]float64{0, 0, 0, 1, 1, 1, 1, 1, 0, 0.5, 0.5, 0.5}, 4, 3)
+	c, edges := s.HistogramDD([]int{2, 2, 2}, nil)
+	if c.GetErr() != nil || len(edges) != 3 || !sameNaN(c, NewArray64([]float64{1, 0, 0, 0, 0, 0, 1, 2}, 2, 2, 2)) {
+		t.Log("HistogramDD failed", c, edges)
+		t.Fail()
+	}
+
+	for i, v := range []struct {
+		a   *Array64
+		err error
+	}{
+		{func() *Array64 { c, _ := s.C().HistogramDD([]int{2, 2}, nil); return c }(), ShapeError},
+		{func() *Array64 { c, _ := s.C().HistogramDD([]int{2, 2, 0}, nil); return c }(), ShapeError},
+		{func() *Array64 { c, _ := s.C().HistogramDD([]int{2, 2, 2}, make([][2]float64, 4)); return c }(), ShapeError},
+		{func() *Array64 { c, _, _ := x.C().Histogram2D(Arange(3), [2]int{2, 2}, [2][2]float64{}); return c }(), ShapeError},
+		{func() *Array64 { c, _, _ := x.C().Histogram2D(nil, [2]int{2, 2}, [2][2]float64{}); return c }(), NilError},
+		{func() *Array64 { c, _, _ := x.C().Histogram2D(y, [2]int{2, -1}, [2][2]float64{}); return c }(), ShapeError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
+
+func TestDigitize(t *testing.T) {
+	t.Parallel()
+	x := NewArray64([]float64{0.2, 6.4, 3.0, 1.6, -1, 10, math.NaN()})
+	for i, v := range []struct {
+		a, res *Array64
+	}{
+		{x.Digitize(NewArray64([]float64{0, 1, 2.5, 4, 10})), NewArray64([]float64{1, 4, 3, 2, 0, 5, 5})},
+		{x.Digitize(NewArray64([]float64{10, 4, 2.5, 1, 0})), NewArray64([]float64{4, 1, 2, 3, 5, 0, 5})},
+		{Arange(6).Reshape(2, 3).Digitize(NewArray64([]float64{1, 3})), NewArray64([]float64{0, 1, 1, 2, 2, 2}, 2, 3)},
+		{NewArray[int32]([]int32{1, 2}, 2).Digitize(NewArray64([]float64{2})), NewArray64([]float64{0, 1})},
+	} {
+		if e := v.a.GetErr(); e != nil || !sameNaN(v.a, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Received", v.a, e)
+			t.Fail()
+		}
+	}
+
+	for i, v := range []struct {
+		a   *Array64
+		err error
+	}{
+		{x.C().Digitize(NewArray64([]float64{0, 2, 1})), ShapeError},
+		{x.C().Digitize(NewArray64([]float64{0, 1, 2, 3}, 2, 2)), ShapeError},
+		{x.C().Digitize(nil), NilError},
+		{NewArray[complex128]([]complex128{1}, 1).Digitize(x), TypeError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
+
+func TestBincount(t *testing.T) {
+	t.Parallel()
+	for i, v := range []struct {
+		a, res *Array64
+	}{
+		{NewArray64([]float64{0, 1, 1, 3, 2, 1, 7}).Bincount(nil), NewArray64([]float64{1, 3, 1, 1, 0, 0, 0, 1})},
+		{NewArray64([]float64{0, 1, 1, 2}).Bincount(NewArray64([]float64{0.5, 1, 2, -1})), NewArray64([]float64{0.5, 3, -1})},
+		{NewArray[uint8]([]uint8{3, 3, 0, 1}, 2, 2).Bincount(nil), NewArray64([]float64{1, 1, 0, 2})},
+	} {
+		if e := v.a.GetErr(); e != nil || !sameNaN(v.a, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Received", v.a, e)
+			t.Fail()
+		}
+	}
+
+	for i, v := range []struct {
+		a   *Array64
+		err error
+	}{
+		{NewArray64([]float64{0, -1}).Bincount(nil), InvIndexError},
+		{NewArray64([]float64{0, 1.5}).Bincount(nil), InvIndexError},
+		{NewArray64([]float64{math.NaN()}).Bincount(nil), InvIndexError},
+		{NewArray64([]float64{1, 1e19}).Bincount(nil), ShapeError},
+		{NewArray64([]float64{3e12}).Bincount(nil), ShapeError},
+		{NewArray64([]float64{math.MaxInt32}).Bincount(nil), ShapeError},
+		{NewArray64([]float64{0, 1}).Bincount(NewArray64([]float64{1})), ShapeError},
+		{NewArray64([]float64{0, 1}).Bincount(NewArray64(nil, 3).Reshape(2)), ReshapeError},
+		{NewArrayB([]bool{true}, 1).Bincount(nil), TypeError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
@@ -35,3 +35,9 @@ func Div(a, b []float64)
 func Fma12(a float64, x, b []float64)
 
 func Fma21(a float64, x, b []float64)
+
+func Sqrt(d []float64)
+
+func Abs(d []float64)
+
+func Clip(lo, hi float64, d []float64)
@@ -394,7 +394,7 @@ vadd_tail_loop:
 	ADDQ  $8, R8
 	ADDQ  $8, R9
 	SUBQ  $1, SI
-	JGE   vadd_tail_loop
+	JG    vadd_tail_loop
 	JMP   vadd_exit
 	
 vadd_avx_loop:
@@ -957,3 +957,138 @@ F21TL:
 
 F21END:
 	RET
+
+// func Sqrt(d []float64)
+TEXT ·Sqrt(SB), NOSPLIT, $0
+	MOVQ d_base+0(FP), R10
+	MOVQ d_len+8(FP), SI
+
+	// zero len return
+	CMPQ SI, $0
+	JE   SQEND
+	SUBQ $4, SI
+	JL   SQTAIL
+
+SQLOOP:  // d[i] | d[i+1] = sqrt(d[i] | d[i+1])
+	MOVUPD 0(R10), X1
+	MOVUPD 16(R10), X2
+	SQRTPD X1, X1
+	SQRTPD X2, X2
+	MOVUPD X1, 0(R10)
+	MOVUPD X2, 16(R10)
+	ADDQ   $32, R10
+	SUBQ   $4, SI
+	JGE    SQLOOP
+
+SQTAIL:
+	ADDQ $4, SI
+	JE   SQEND
+
+SQTL:
+	MOVSD  0(R10), X1
+	SQRTSD X1, X1
+	MOVSD  X1, 0(R10)
+	ADDQ   $8, R10
+	SUBQ   $1, SI
+	JG     SQTL
+
+SQEND:
+	RET
+
+// func Abs(d []float64)
+TEXT ·Abs(SB), NOSPLIT, $0
+	MOVQ d_base+0(FP), R10
+	MOVQ d_len+8(FP), SI
+
+	// zero len return
+	CMPQ SI, $0
+	JE   ABEND
+
+	// load sign mask
+	MOVQ   $0x7FFFFFFFFFFFFFFF, AX
+	MOVQ   AX, X0
+	SHUFPD $0, X0, X0
+
+	SUBQ $4, SI
+	JL   ABTAIL
+
+ABLOOP:  // clear the sign bit of d[i] | d[i+1]
+	MOVUPD 0(R10), X1
+	MOVUPD 16(R10), X2
+	ANDPD  X0, X1
+	ANDPD  X0, X2
+	MOVUPD X1, 0(R10)
+	MOVUPD X2, 16(R10)
+	ADDQ   $32, R10
+	SUBQ   $4, SI
+	JGE    ABLOOP
+
+ABTAIL:
+	ADDQ $4, SI
+	JE   ABEND
+
+ABTL:
+	MOVSD 0(R10), X1
+	ANDPD X0, X1
+	MOVSD X1, 0(R10)
+	ADDQ  $8, R10
+	SUBQ  $1, SI
+	JG    ABTL
+
+ABEND:
+	RET
+
+// func Clip(lo, hi float64, d []float64)
+// MAXPD and MINPD return the source operand when either value is NaN,
+// so d is always the source to keep NaN values.
+TEXT ·Clip(SB), NOSPLIT, $0
+	MOVQ d_base+16(FP), R10
+	MOVQ d_len+24(FP), SI
+
+	// zero len return
+	CMPQ SI, $0
+	JE   CLEND
+
+	// load bounds
+	MOVSD  lo+0(FP), X0
+	SHUFPD $0, X0, X0
+	MOVSD  hi+8(FP), X5
+	SHUFPD $0, X5, X5
+
+	SUBQ $4, SI
+	JL   CLTAIL
+
+CLLOOP:  // d[i] | d[i+1] = min(hi, max(lo, d[i] | d[i+1]))
+	MOVUPD 0(R10), X1
+	MOVUPD 16(R10), X2
+	MOVAPD X0, X3
+	MOVAPD X0, X4
+	MAXPD  X1, X3
+	MAXPD  X2, X4
+	MOVAPD X5, X1
+	MOVAPD X5, X2
+	MINPD  X3, X1
+	MINPD  X4, X2
+	MOVUPD X1, 0(R10)
+	MOVUPD X2, 16(R10)
+	ADDQ   $32, R10
+	SUBQ   $4, SI
+	JGE    CLLOOP
+
+CLTAIL:
+	ADDQ $4, SI
+	JE   CLEND
+
+CLTL:
+	MOVSD  0(R10), X1
+	MOVAPD X0, X3
+	MAXSD  X1, X3
+	MOVAPD X5, X1
+	MINSD  X3, X1
+	MOVSD  X1, 0(R10)
+	ADDQ   $8, R10
+	SUBQ   $1, SI
+	JG     CLTL
+
+CLEND:
+	RET
@@ -2,6 +2,8 @@
 
 package asm
 
+import "math"
+
 var (
 	Sse3Supt, AvxSupt, Avx2Supt, FmaSupt bool
 )
@@ -108,3 +110,27 @@ func Fma21(a float64, x, b []float64) {
 		x[i] = x[i]*b[j] + a
 	}
 }
+
+func Sqrt(d []float64) {
+	for i := range d {
+		d[i] = math.Sqrt(d[i])
+	}
+}
+
+func Abs(d []float64) {
+	for i := range d {
+		d[i] = math.Abs(d[i])
+	}
+}
+
+func Clip(lo, hi float64, d []float64) {
+	for i, v := range d {
+		if v < lo {
+			v = lo
+		}
+		if v > hi {
+			v = hi
+		}
+		d[i] = v
+	}
+}
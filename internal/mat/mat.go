@@ -0,0 +1,95 @@
+// Package mat holds the dense matrix kernels shared by numgo and the linalg subpackage.
+// Matrices are stored in row-major order in float64 slices.
+package mat
+
+import (
+	"math"
+	"sort"
+)
+
+// Eps is the difference between 1 and the next larger float64 value.
+const Eps = 0x1p-52
+
+// MaxSweeps limits the number of sweeps over all pairs of columns made by the Jacobi methods.
+const MaxSweeps = 100
+
+// Rotate applies the plane rotation (c, s) to columns i and j of the m×n matrix d.
+func Rotate(d []float64, m, n, i, j int, c, s float64) {
+	for r := 0; r < m; r++ {
+		x, y := d[r*n+i], d[r*n+j]
+		d[r*n+i], d[r*n+j] = c*x-s*y, s*x+c*y
+	}
+}
+
+// Transpose returns the transpose of the m×n matrix d.
+func Transpose(d []float64, m, n int) []float64 {
+	t := make([]float64, len(d))
+	for r := 0; r < m; r++ {
+		for c := 0; c < n; c++ {
+			t[c*m+r] = d[r*n+c]
+		}
+	}
+	return t
+}
+
+// SVD calculates the thin singular value decomposition of the m×n matrix d with one-sided Jacobi rotations.
+// u is m×k, s holds the k singular values in decreasing order, and v is n×k, with k = min(m, n).
+// Columns of u for zero singular values are left at zero.  ok is false if the rotations didn't converge.
+func SVD(d []float64, m, n int) (u, s, v []float64, ok bool) {
+	if m < n {
+		v, s, u, ok = SVD(Transpose(d, m, n), n, m)
+		return u, s, v, ok
+	}
+
+	w, vr := append([]float64(nil), d...), make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		vr[i*n+i] = 1
+	}
+
+	for sweep := 0; !ok && sweep < MaxSweeps; sweep++ {
+		ok = true
+		for i := 0; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				var alpha, beta, gamma float64
+				for r := 0; r < m; r++ {
+					x, y := w[r*n+i], w[r*n+j]
+					alpha, beta, gamma = alpha+x*x, beta+y*y, gamma+x*y
+				}
+				if math.Abs(gamma) <= Eps*math.Sqrt(alpha)*math.Sqrt(beta) {
+					continue
+				}
+
+				ok = false
+				zeta := (beta - alpha) / (2 * gamma)
+				t := math.Copysign(1, zeta) / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				c := 1 / math.Sqrt(1+t*t)
+				Rotate(w, m, n, i, j, c, c*t)
+				Rotate(vr, n, n, i, j, c, c*t)
+			}
+		}
+	}
+	if !ok {
+		return nil, nil, nil, false
+	}
+
+	s, order := make([]float64, n), make([]int, n)
+	for c := 0; c < n; c++ {
+		for r := 0; r < m; r++ {
+			s[c] = math.Hypot(s[c], w[r*n+c])
+		}
+		order[c] = c
+	}
+	sort.SliceStable(order, func(i, j int) bool { return s[order[i]] > s[order[j]] })
+
+	u, v, sv := make([]float64, m*n), make([]float64, n*n), make([]float64, n)
+	for c, o := range order {
+		sv[c] = s[o]
+		for r := 0; r < m && s[o] != 0; r++ {
+			u[r*n+c] = w[r*n+o] / s[o]
+		}
+		for r := 0; r < n; r++ {
+			v[r*n+c] = vr[r*n+o]
+		}
+	}
+	return u, sv, v, true
+}
@@ -0,0 +1,111 @@
+package linalg
+
+import (
+	"math"
+
+	"github.com/Kunde21/numgo"
+)
+
+// LU calculates the LU factorization with partial pivoting of each square matrix in a,
+// such that a = p·l·u.  p is a permutation matrix, l is lower triangular with a unit diagonal,
+// and u is upper triangular.  Singular matrices are factored without an error.
+func LU(a *numgo.Array64) (p, l, u *numgo.Array64) {
+	s, err := square(a, "LU")
+	if err != nil {
+		return err, err, err
+	}
+
+	n, sz := s.n, s.n*s.n
+	pd, ld, ud := make([]float64, len(s.data)), make([]float64, len(s.data)), make([]float64, len(s.data))
+	for i := 0; i < s.count(); i++ {
+		lu := s.mat(i)
+		piv, _, _ := factorLU(lu, n)
+		pm, lm, um := pd[i*sz:(i+1)*sz], ld[i*sz:(i+1)*sz], ud[i*sz:(i+1)*sz]
+		for r := 0; r < n; r++ {
+			pm[piv[r]*n+r] = 1
+			copy(lm[r*n:r*n+r], lu[r*n:r*n+r])
+			lm[r*n+r] = 1
+			copy(um[r*n+r:(r+1)*n], lu[r*n+r:(r+1)*n])
+		}
+	}
+
+	sh := shape(s.batch, n, n)
+	return numgo.NewArray64(pd).Reshape(sh...), numgo.NewArray64(ld).Reshape(sh...), numgo.NewArray64(ud).Reshape(sh...)
+}
+
+// QR calculates the QR factorization of each m×n matrix in a, such that a = q·r.
+// q has orthonormal columns and r is upper triangular.
+//
+// When full is set, q is m×m and r is m×n.  Otherwise the reduced factorization is returned,
+// where q is m×k and r is k×n, with k = min(m, n).
+func QR(a *numgo.Array64, full bool) (q, r *numgo.Array64) {
+	s, err := matrices(a, "QR")
+	if err != nil {
+		return err, err
+	}
+
+	m, n, k := s.m, s.n, s.m
+	if n < k {
+		k = n
+	}
+	qc := k
+	if full {
+		qc = m
+	}
+
+	qd, rd := make([]float64, s.count()*m*qc), make([]float64, s.count()*qc*n)
+	for i := 0; i < s.count(); i++ {
+		d := s.mat(i)
+		tau, _ := factorQR(d, m, n, false)
+
+		qm := qd[i*m*qc : (i+1)*m*qc]
+		for j := 0; j < qc; j++ {
+			qm[j*qc+j] = 1
+		}
+		for j := k - 1; j >= 0; j-- {
+			reflect(d, tau, n, j, qm, m, qc)
+		}
+
+		rm := rd[i*qc*n : (i+1)*qc*n]
+		for j := 0; j < k; j++ {
+			copy(rm[j*n+j:(j+1)*n], d[j*n+j:(j+1)*n])
+		}
+	}
+	return numgo.NewArray64(qd).Reshape(shape(s.batch, m, qc)...), numgo.NewArray64(rd).Reshape(shape(s.batch, qc, n)...)
+}
+
+// Cholesky calculates the Cholesky factorization of each symmetric positive definite matrix in a,
+// such that a = l·lᵀ, where l is lower triangular.  Only the lower triangle of a is used.
+//
+// A NotPositiveDefinite error is returned if any matrix in a is not positive definite.
+func Cholesky(a *numgo.Array64) *numgo.Array64 {
+	s, err := square(a, "Cholesky")
+	if err != nil {
+		return err
+	}
+
+	n := s.n
+	ld := make([]float64, len(s.data))
+	for i := 0; i < s.count(); i++ {
+		am, lm := s.mat(i), ld[i*n*n:(i+1)*n*n]
+		for j := 0; j < n; j++ {
+			d := am[j*n+j]
+			for c := 0; c < j; c++ {
+				d -= lm[j*n+c] * lm[j*n+c]
+			}
+			if !(d > 0) {
+				return numgo.ErrArray[float64](numgo.NotPositiveDefinite, "Matrix received by Cholesky() is not positive definite.  Stack index: %v", s.index(i))
+			}
+			lm[j*n+j] = math.Sqrt(d)
+
+			for r := j + 1; r < n; r++ {
+				v := am[r*n+j]
+				for c := 0; c < j; c++ {
+					v -= lm[r*n+c] * lm[j*n+c]
+				}
+				lm[r*n+j] = v / lm[j*n+j]
+			}
+		}
+	}
+	return numgo.NewArray64(ld).Reshape(shape(s.batch, n, n)...)
+}
@@ -0,0 +1,211 @@
+package linalg
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Kunde21/numgo"
+	"github.com/Kunde21/numgo/internal/mat"
+)
+
+// mul multiplies the m×k matrix a by the k×n matrix b.
+func mul(a, b []float64, m, k, n int) []float64 {
+	r := make([]float64, m*n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			for l := 0; l < k; l++ {
+				r[i*n+j] += a[i*k+l] * b[l*n+j]
+			}
+		}
+	}
+	return r
+}
+
+// eye creates an n×n identity matrix.
+func eye(n int) []float64 {
+	r := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		r[i*n+i] = 1
+	}
+	return r
+}
+
+// same tests that two slices hold the same values, within a small tolerance.
+func same(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-9*math.Max(1, math.Abs(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLU(t *testing.T) {
+	for i, a := range []*numgo.Array64{
+		numgo.NewArray64([]float64{1, 2, 3, 4}, 2, 2),
+		numgo.NewArray64([]float64{0, 1, 1, 0}, 2, 2),
+		numgo.NewArray64([]float64{1, 2, 2, 4}, 2, 2),
+		numgo.RandArray64(-5, 10, 5, 5),
+		numgo.RandArray64(-5, 10, 2, 3, 4, 4),
+		numgo.NewArray64(nil, 3, 3),
+	} {
+		p, l, u := LU(a)
+		if e := p.GetErr(); e != nil || l.HasErr() || u.HasErr() {
+			t.Log("Test", i, "Unexpected error", e)
+			t.Fail()
+			continue
+		}
+
+		sh := a.Shape()
+		n := sh[len(sh)-1]
+		if ps := p.Shape(); len(ps) != len(sh) || ps[len(ps)-1] != n || ps[0] != sh[0] {
+			t.Log("Test", i, "Expected shape", sh, "Got", ps)
+			t.Fail()
+			continue
+		}
+
+		ad, pd, ld, ud := a.Values(), p.Values(), l.Values(), u.Values()
+		for m := 0; m < len(ad)/(n*n); m++ {
+			am, pm, lm, um := ad[m*n*n:(m+1)*n*n], pd[m*n*n:(m+1)*n*n], ld[m*n*n:(m+1)*n*n], nth(ud, m, n)
+			if !same(mul(pm, mul(lm, um, n, n, n), n, n, n), am) {
+				t.Log("Test", i, "Matrix", m, "p·l·u != a", p, l, u)
+				t.Fail()
+			}
+			for r := 0; r < n; r++ {
+				if lm[r*n+r] != 1 {
+					t.Log("Test", i, "l does not have a unit diagonal", l)
+					t.Fail()
+				}
+				for c := r + 1; c < n; c++ {
+					if lm[r*n+c] != 0 || um[c*n+r] != 0 {
+						t.Log("Test", i, "Factors are not triangular", l, u)
+						t.Fail()
+					}
+				}
+			}
+		}
+	}
+
+	if p, l, u := LU(numgo.NewArray64(nil, 2, 3)); p.GetErr() != numgo.ShapeError || !l.HasErr() || !u.HasErr() {
+		t.Log("Expected ShapeError Got", p, l, u)
+		t.Fail()
+	}
+}
+
+// nth returns the m-th n×n matrix in d.
+func nth(d []float64, m, n int) []float64 {
+	return d[m*n*n : (m+1)*n*n]
+}
+
+func TestQR(t *testing.T) {
+	for i, v := range []struct {
+		a    *numgo.Array64
+		full bool
+		qs   []int
+		rs   []int
+	}{
+		{numgo.NewArray64([]float64{12, -51, 4, 6, 167, -68, -4, 24, -41}, 3, 3), false, []int{3, 3}, []int{3, 3}},
+		{numgo.RandArray64(-1, 2, 5, 3), false, []int{5, 3}, []int{3, 3}},
+		{numgo.RandArray64(-1, 2, 5, 3), true, []int{5, 5}, []int{5, 3}},
+		{numgo.RandArray64(-1, 2, 3, 5), false, []int{3, 3}, []int{3, 5}},
+		{numgo.RandArray64(-1, 2, 3, 5), true, []int{3, 3}, []int{3, 5}},
+		{numgo.FullArray64(1, 4, 3), true, []int{4, 4}, []int{4, 3}},
+		{numgo.RandArray64(-1, 2, 2, 4, 2), false, []int{2, 4, 2}, []int{2, 2, 2}},
+		{numgo.NewArray64(nil, 3, 2), true, []int{3, 3}, []int{3, 2}},
+	} {
+		q, r := QR(v.a, v.full)
+		if e := q.GetErr(); e != nil || r.HasErr() {
+			t.Log("Test", i, "Unexpected error", e)
+			t.Fail()
+			continue
+		}
+		qs, rs := q.Shape(), r.Shape()
+		if !same(toF(qs), toF(v.qs)) || !same(toF(rs), toF(v.rs)) {
+			t.Log("Test", i, "Expected shapes", v.qs, v.rs, "Got", qs, rs)
+			t.Fail()
+			continue
+		}
+
+		m, k, n := qs[len(qs)-2], qs[len(qs)-1], rs[len(rs)-1]
+		ad, qd, rd := v.a.Values(), q.Values(), r.Values()
+		for b := 0; b < len(ad)/(m*n); b++ {
+			qm, rm := qd[b*m*k:(b+1)*m*k], rd[b*k*n:(b+1)*k*n]
+			if !same(mul(qm, rm, m, k, n), ad[b*m*n:(b+1)*m*n]) {
+				t.Log("Test", i, "q·r != a", q, r)
+				t.Fail()
+			}
+			if !same(mul(mat.Transpose(qm, m, k), qm, k, m, k), eye(k)) {
+				t.Log("Test", i, "q is not orthonormal", q)
+				t.Fail()
+			}
+			for row := 0; row < k; row++ {
+				for c := 0; c < row && c < n; c++ {
+					if rm[row*n+c] != 0 {
+						t.Log("Test", i, "r is not upper triangular", r)
+						t.Fail()
+					}
+				}
+			}
+		}
+	}
+
+	if q, r := QR(numgo.NewArray64(nil, 3), false); q.GetErr() != numgo.ShapeError || !r.HasErr() {
+		t.Log("Expected ShapeError Got", q, r)
+		t.Fail()
+	}
+}
+
+// toF converts a shape to float64 values for comparisons.
+func toF(s []int) []float64 {
+	r := make([]float64, len(s))
+	for i, v := range s {
+		r[i] = float64(v)
+	}
+	return r
+}
+
+func TestCholesky(t *testing.T) {
+	b := numgo.RandArray64(-1, 2, 5, 5)
+	spd := b.T().MatProd(b).Add(numgo.Identity(5))
+	for i, v := range []struct {
+		a   *numgo.Array64
+		res []float64
+		err error
+	}{
+		{numgo.NewArray64([]float64{4, 2, 2, 3}, 2, 2), []float64{2, 0, 1, math.Sqrt2}, nil},
+		{numgo.NewArray64([]float64{4, 99, 2, 3}, 2, 2), []float64{2, 0, 1, math.Sqrt2}, nil},
+		{numgo.NewArray64([]float64{4, 2, 2, 3, 9, 0, 0, 1}, 2, 2, 2), []float64{2, 0, 1, math.Sqrt2, 3, 0, 0, 1}, nil},
+		{spd, nil, nil},
+		{numgo.NewArray64([]float64{1, 2, 2, 1}, 2, 2), nil, numgo.NotPositiveDefinite},
+		{numgo.NewArray64([]float64{1, 0, 0, 1, 0, 0, 0, 0}, 2, 2, 2), nil, numgo.NotPositiveDefinite},
+		{numgo.NewArray64([]float64{math.NaN()}, 1, 1), nil, numgo.NotPositiveDefinite},
+		{numgo.NewArray64(nil, 2, 3), nil, numgo.ShapeError},
+	} {
+		l := Cholesky(v.a)
+		if e := l.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err != nil {
+			continue
+		}
+		if v.res != nil && !same(l.Values(), v.res) {
+			t.Log("Test", i, "Expected", v.res, "Got", l)
+			t.Fail()
+		}
+		if v.res == nil && !same(l.MatProd(l.T()).Values(), v.a.Values()) {
+			t.Log("Test", i, "l·lᵀ != a", l)
+			t.Fail()
+		}
+	}
+
+	_, d, _ := Cholesky(numgo.NewArray64([]float64{1, 0, 0, 1, 0, 0, 0, 0}, 2, 2, 2)).GetDebug()
+	if d != "Matrix received by Cholesky() is not positive definite.  Stack index: [1]" {
+		t.Log("Incorrect debug string:", d)
+		t.Fail()
+	}
+}
@@ -0,0 +1,593 @@
+package linalg
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Kunde21/numgo"
+	"github.com/Kunde21/numgo/internal/mat"
+)
+
+// eigh calculates the eigenvalues and eigenvectors of the symmetric n×n matrix d with cyclic Jacobi rotations.
+// Eigenvalues are returned in increasing order, with the matching eigenvectors in the columns of v.
+// ok is false if the rotations didn't converge.
+func eigh(d []float64, n int) (w, v []float64, ok bool) {
+	a, vr := append([]float64(nil), d...), make([]float64, n*n)
+	var norm float64
+	for i := 0; i < n; i++ {
+		vr[i*n+i] = 1
+		for j := 0; j < n; j++ {
+			norm = math.Hypot(norm, a[i*n+j])
+		}
+	}
+
+	for sweep := 0; sweep < mat.MaxSweeps; sweep++ {
+		var off float64
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				off = math.Hypot(off, a[i*n+j])
+			}
+		}
+		if ok = off <= eps*norm; ok {
+			break
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p*n+q] == 0 {
+					continue
+				}
+				theta := (a[q*n+q] - a[p*n+p]) / (2 * a[p*n+q])
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(1+theta*theta))
+				c := 1 / math.Sqrt(1+t*t)
+				s := c * t
+
+				mat.Rotate(a, n, n, p, q, c, s)
+				for k := 0; k < n; k++ {
+					x, y := a[p*n+k], a[q*n+k]
+					a[p*n+k], a[q*n+k] = c*x-s*y, s*x+c*y
+				}
+				a[p*n+q], a[q*n+p] = 0, 0
+				mat.Rotate(vr, n, n, p, q, c, s)
+			}
+		}
+	}
+	if !ok {
+		return nil, nil, false
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return a[order[i]*n+order[i]] < a[order[j]*n+order[j]] })
+
+	w, v = make([]float64, n), make([]float64, n*n)
+	for c, o := range order {
+		w[c] = a[o*n+o]
+		for r := 0; r < n; r++ {
+			v[r*n+c] = vr[r*n+o]
+		}
+	}
+	return w, v, true
+}
+
+// Eigh calculates the eigenvalues and eigenvectors of each symmetric matrix in a.
+// Only the lower triangle of a is used.
+//
+// w holds the eigenvalues in increasing order, and the columns of v hold the matching
+// normalized eigenvectors, such that a·v = v·diag(w).  The decomposition is calculated with
+// Jacobi rotations, and a NoConvergence error is returned if they don't converge.
+func Eigh(a *numgo.Array64) (w, v *numgo.Array64) {
+	s, err := square(a, "Eigh")
+	if err != nil {
+		return err, err
+	}
+
+	n := s.n
+	wd, vd := make([]float64, 0, s.count()*n), make([]float64, 0, len(s.data))
+	for i := 0; i < s.count(); i++ {
+		am := s.mat(i)
+		for r := 0; r < n; r++ {
+			for c := r + 1; c < n; c++ {
+				am[r*n+c] = am[c*n+r]
+			}
+		}
+
+		wm, vm, ok := eigh(am, n)
+		if !ok {
+			e := numgo.ErrArray[float64](numgo.NoConvergence, "Eigh() did not converge.  Stack index: %v", s.index(i))
+			return e, e
+		}
+		wd, vd = append(wd, wm...), append(vd, vm...)
+	}
+	return numgo.NewArray64(wd).Reshape(shape(s.batch, n)...), numgo.NewArray64(vd).Reshape(shape(s.batch, n, n)...)
+}
+
+// hessenberg reduces the n×n matrix h to upper Hessenberg form with orthogonal similarity
+// transformations, in place, and returns the accumulated transformations.
+func hessenberg(h []float64, n int) (v []float64) {
+	ort, v := make([]float64, n), make([]float64, n*n)
+	for m := 1; m < n-1; m++ {
+		var scale float64
+		for i := m; i < n; i++ {
+			scale += math.Abs(h[i*n+m-1])
+		}
+		if scale == 0 {
+			continue
+		}
+
+		var hh float64
+		for i := n - 1; i >= m; i-- {
+			ort[i] = h[i*n+m-1] / scale
+			hh += ort[i] * ort[i]
+		}
+		g := math.Sqrt(hh)
+		if ort[m] > 0 {
+			g = -g
+		}
+		hh -= ort[m] * g
+		ort[m] -= g
+
+		for j := m; j < n; j++ {
+			var f float64
+			for i := n - 1; i >= m; i-- {
+				f += ort[i] * h[i*n+j]
+			}
+			f /= hh
+			for i := m; i < n; i++ {
+				h[i*n+j] -= f * ort[i]
+			}
+		}
+		for i := 0; i < n; i++ {
+			var f float64
+			for j := n - 1; j >= m; j-- {
+				f += ort[j] * h[i*n+j]
+			}
+			f /= hh
+			for j := m; j < n; j++ {
+				h[i*n+j] -= f * ort[j]
+			}
+		}
+		ort[m] *= scale
+		h[m*n+m-1] = scale * g
+	}
+
+	for i := 0; i < n; i++ {
+		v[i*n+i] = 1
+	}
+	for m := n - 2; m >= 1; m-- {
+		if h[m*n+m-1] == 0 {
+			continue
+		}
+		for i := m + 1; i < n; i++ {
+			ort[i] = h[i*n+m-1]
+		}
+		for j := m; j < n; j++ {
+			var g float64
+			for i := m; i < n; i++ {
+				g += ort[i] * v[i*n+j]
+			}
+			// Double division avoids possible underflow
+			g = (g / ort[m]) / h[m*n+m-1]
+			for i := m; i < n; i++ {
+				v[i*n+j] += g * ort[i]
+			}
+		}
+	}
+	return v
+}
+
+// schur reduces the n×n upper Hessenberg matrix h to real Schur form with shifted QR steps,
+// accumulating the transformations in v, and then replaces v with the eigenvectors of the
+// original matrix.  Eigenvalue i is d[i] + e[i]i.  For a complex conjugate pair with e[i] > 0,
+// the eigenvector of eigenvalue i is column i of v plus column i+1 times i.
+// ok is false if the QR steps didn't converge.
+//
+// This follows the hqr2 procedure of EISPACK, as adapted by the JAMA library.
+func schur(h, v []float64, nn int) (d, e []float64, ok bool) {
+	d, e = make([]float64, nn), make([]float64, nn)
+	H, V := make([][]float64, nn), make([][]float64, nn)
+	for i := range H {
+		H[i], V[i] = h[i*nn:(i+1)*nn], v[i*nn:(i+1)*nn]
+	}
+
+	var norm float64
+	for i := 0; i < nn; i++ {
+		for j := i - 1; j < nn; j++ {
+			if j >= 0 {
+				norm += math.Abs(H[i][j])
+			}
+		}
+	}
+
+	if norm == 0 {
+		return d, e, true
+	}
+
+	var exshift, p, q, r, s, z, t, w, x, y float64
+	n, iter, itn := nn-1, 0, 30*nn
+	if itn < 300 {
+		itn = 300
+	}
+	for n >= 0 {
+		// Look for a single small sub-diagonal element
+		l := n
+		for l > 0 {
+			s = math.Abs(H[l-1][l-1]) + math.Abs(H[l][l])
+			if s == 0 {
+				s = norm
+			}
+			if math.Abs(H[l][l-1]) < eps*s {
+				break
+			}
+			l--
+		}
+
+		switch {
+		case l == n:
+			// One root found
+			H[n][n] += exshift
+			d[n], e[n] = H[n][n], 0
+			n, iter = n-1, 0
+
+		case l == n-1:
+			// Two roots found
+			w = H[n][n-1] * H[n-1][n]
+			p = (H[n-1][n-1] - H[n][n]) / 2
+			q = p*p + w
+			z = math.Sqrt(math.Abs(q))
+			H[n][n] += exshift
+			H[n-1][n-1] += exshift
+			x = H[n][n]
+
+			if q >= 0 {
+				// Real pair
+				if p >= 0 {
+					z = p + z
+				} else {
+					z = p - z
+				}
+				d[n-1], d[n] = x+z, x+z
+				if z != 0 {
+					d[n] = x - w/z
+				}
+				e[n-1], e[n] = 0, 0
+				x = H[n][n-1]
+				s = math.Abs(x) + math.Abs(z)
+				p, q = x/s, z/s
+				r = math.Sqrt(p*p + q*q)
+				p, q = p/r, q/r
+
+				for j := n - 1; j < nn; j++ {
+					z = H[n-1][j]
+					H[n-1][j] = q*z + p*H[n][j]
+					H[n][j] = q*H[n][j] - p*z
+				}
+				for i := 0; i <= n; i++ {
+					z = H[i][n-1]
+					H[i][n-1] = q*z + p*H[i][n]
+					H[i][n] = q*H[i][n] - p*z
+				}
+				for i := 0; i < nn; i++ {
+					z = V[i][n-1]
+					V[i][n-1] = q*z + p*V[i][n]
+					V[i][n] = q*V[i][n] - p*z
+				}
+			} else {
+				// Complex pair
+				d[n-1], d[n] = x+p, x+p
+				e[n-1], e[n] = z, -z
+			}
+			n, iter = n-2, 0
+
+		default:
+			if itn--; itn < 0 {
+				return nil, nil, false
+			}
+
+			// Form shift
+			x, y, w = H[n][n], 0, 0
+			if l < n {
+				y = H[n-1][n-1]
+				w = H[n][n-1] * H[n-1][n]
+			}
+
+			// Wilkinson's original ad hoc shift
+			if iter == 10 {
+				exshift += x
+				for i := 0; i <= n; i++ {
+					H[i][i] -= x
+				}
+				s = math.Abs(H[n][n-1]) + math.Abs(H[n-1][n-2])
+				x, y = 0.75*s, 0.75*s
+				w = -0.4375 * s * s
+			}
+
+			// MATLAB's ad hoc shift
+			if iter == 30 {
+				s = (y - x) / 2
+				s = s*s + w
+				if s > 0 {
+					s = math.Sqrt(s)
+					if y < x {
+						s = -s
+					}
+					s = x - w/((y-x)/2+s)
+					for i := 0; i <= n; i++ {
+						H[i][i] -= s
+					}
+					exshift += s
+					x, y, w = 0.964, 0.964, 0.964
+				}
+			}
+			iter++
+
+			// Look for two consecutive small sub-diagonal elements
+			m := n - 2
+			for ; m >= l; m-- {
+				z = H[m][m]
+				r = x - z
+				s = y - z
+				p = (r*s-w)/H[m+1][m] + H[m][m+1]
+				q = H[m+1][m+1] - z - r - s
+				r = H[m+2][m+1]
+				s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+				p, q, r = p/s, q/s, r/s
+				if m == l {
+					break
+				}
+				if math.Abs(H[m][m-1])*(math.Abs(q)+math.Abs(r)) <
+					eps*(math.Abs(p)*(math.Abs(H[m-1][m-1])+math.Abs(z)+math.Abs(H[m+1][m+1]))) {
+					break
+				}
+			}
+
+			for i := m + 2; i <= n; i++ {
+				H[i][i-2] = 0
+				if i > m+2 {
+					H[i][i-3] = 0
+				}
+			}
+
+			// Double QR step involving rows l:n and columns m:n
+			for k := m; k <= n-1; k++ {
+				notlast := k != n-1
+				if k != m {
+					p, q, r = H[k][k-1], H[k+1][k-1], 0
+					if notlast {
+						r = H[k+2][k-1]
+					}
+					if x = math.Abs(p) + math.Abs(q) + math.Abs(r); x == 0 {
+						continue
+					}
+					p, q, r = p/x, q/x, r/x
+				}
+
+				if s = math.Sqrt(p*p + q*q + r*r); p < 0 {
+					s = -s
+				}
+				if s == 0 {
+					continue
+				}
+				if k != m {
+					H[k][k-1] = -s * x
+				} else if l != m {
+					H[k][k-1] = -H[k][k-1]
+				}
+				p += s
+				x, y, z = p/s, q/s, r/s
+				q, r = q/p, r/p
+
+				// Row modification
+				for j := k; j < nn; j++ {
+					p = H[k][j] + q*H[k+1][j]
+					if notlast {
+						p += r * H[k+2][j]
+						H[k+2][j] -= p * z
+					}
+					H[k][j] -= p * x
+					H[k+1][j] -= p * y
+				}
+
+				// Column modification
+				for i := 0; i <= n && i <= k+3; i++ {
+					p = x*H[i][k] + y*H[i][k+1]
+					if notlast {
+						p += z * H[i][k+2]
+						H[i][k+2] -= p * r
+					}
+					H[i][k] -= p
+					H[i][k+1] -= p * q
+				}
+
+				// Accumulate transformations
+				for i := 0; i < nn; i++ {
+					p = x*V[i][k] + y*V[i][k+1]
+					if notlast {
+						p += z * V[i][k+2]
+						V[i][k+2] -= p * r
+					}
+					V[i][k] -= p
+					V[i][k+1] -= p * q
+				}
+			}
+		}
+	}
+
+	// Back substitute to find the vectors of the upper triangular form
+	for n = nn - 1; n >= 0; n-- {
+		p, q = d[n], e[n]
+
+		switch {
+		case q == 0:
+			// Real vector
+			l := n
+			H[n][n] = 1
+			for i := n - 1; i >= 0; i-- {
+				w = H[i][i] - p
+				r = 0
+				for j := l; j <= n; j++ {
+					r += H[i][j] * H[j][n]
+				}
+				if e[i] < 0 {
+					z, s = w, r
+					continue
+				}
+
+				l = i
+				if e[i] == 0 {
+					if w != 0 {
+						H[i][n] = -r / w
+					} else {
+						H[i][n] = -r / (eps * norm)
+					}
+				} else {
+					// Solve real equations
+					x, y = H[i][i+1], H[i+1][i]
+					q = (d[i]-p)*(d[i]-p) + e[i]*e[i]
+					t = (x*s - z*r) / q
+					H[i][n] = t
+					if math.Abs(x) > math.Abs(z) {
+						H[i+1][n] = (-r - w*t) / x
+					} else {
+						H[i+1][n] = (-s - y*t) / z
+					}
+				}
+
+				// Overflow control
+				if t = math.Abs(H[i][n]); (eps*t)*t > 1 {
+					for j := i; j <= n; j++ {
+						H[j][n] /= t
+					}
+				}
+			}
+
+		case q < 0:
+			// Complex vector
+			l := n - 1
+
+			// Last vector component imaginary so matrix is triangular
+			if math.Abs(H[n][n-1]) > math.Abs(H[n-1][n]) {
+				H[n-1][n-1] = q / H[n][n-1]
+				H[n-1][n] = -(H[n][n] - p) / H[n][n-1]
+			} else {
+				c := complex(0, -H[n-1][n]) / complex(H[n-1][n-1]-p, q)
+				H[n-1][n-1], H[n-1][n] = real(c), imag(c)
+			}
+			H[n][n-1], H[n][n] = 0, 1
+
+			for i := n - 2; i >= 0; i-- {
+				var ra, sa float64
+				for j := l; j <= n; j++ {
+					ra += H[i][j] * H[j][n-1]
+					sa += H[i][j] * H[j][n]
+				}
+				w = H[i][i] - p
+
+				if e[i] < 0 {
+					z, r, s = w, ra, sa
+					continue
+				}
+
+				l = i
+				if e[i] == 0 {
+					c := complex(-ra, -sa) / complex(w, q)
+					H[i][n-1], H[i][n] = real(c), imag(c)
+				} else {
+					// Solve complex equations
+					x, y = H[i][i+1], H[i+1][i]
+					vr := (d[i]-p)*(d[i]-p) + e[i]*e[i] - q*q
+					vi := (d[i] - p) * 2 * q
+					if vr == 0 && vi == 0 {
+						vr = eps * norm * (math.Abs(w) + math.Abs(q) + math.Abs(x) + math.Abs(y) + math.Abs(z))
+					}
+					c := complex(x*r-z*ra+q*sa, x*s-z*sa-q*ra) / complex(vr, vi)
+					H[i][n-1], H[i][n] = real(c), imag(c)
+					if math.Abs(x) > math.Abs(z)+math.Abs(q) {
+						H[i+1][n-1] = (-ra - w*H[i][n-1] + q*H[i][n]) / x
+						H[i+1][n] = (-sa - w*H[i][n] - q*H[i][n-1]) / x
+					} else {
+						c := complex(-r-y*H[i][n-1], -s-y*H[i][n]) / complex(z, q)
+						H[i+1][n-1], H[i+1][n] = real(c), imag(c)
+					}
+				}
+
+				// Overflow control
+				if t = math.Max(math.Abs(H[i][n-1]), math.Abs(H[i][n])); (eps*t)*t > 1 {
+					for j := i; j <= n; j++ {
+						H[j][n-1] /= t
+						H[j][n] /= t
+					}
+				}
+			}
+		}
+	}
+
+	// Back transformation to get the eigenvectors of the original matrix
+	for j := nn - 1; j >= 0; j-- {
+		for i := 0; i < nn; i++ {
+			z = 0
+			for k := 0; k <= j; k++ {
+				z += V[i][k] * H[k][j]
+			}
+			V[i][j] = z
+		}
+	}
+	return d, e, true
+}
+
+// Eig calculates the eigenvalues and eigenvectors of each square matrix in a.
+//
+// Eigenvalues of a general real matrix can be complex, so w and v are complex arrays.
+// The columns of v hold the eigenvectors matching the values in w, normalized to unit length,
+// such that a·v = v·diag(w).  Eigenvalues are not returned in any particular order.
+//
+// Each matrix is reduced to Hessenberg form and then to real Schur form with shifted QR steps.
+// A NoConvergence error is returned if the QR steps don't converge.
+func Eig(a *numgo.Array64) (w, v *numgo.ArrayC128) {
+	s, err := square(a, "Eig")
+	if err != nil {
+		e := numgo.AsType[complex128](err, numgo.RoundTrunc, false)
+		return e, e
+	}
+
+	n := s.n
+	wd, vd := make([]complex128, 0, s.count()*n), make([]complex128, 0, len(s.data))
+	for i := 0; i < s.count(); i++ {
+		h := s.mat(i)
+		vr := hessenberg(h, n)
+		re, im, ok := schur(h, vr, n)
+		if !ok {
+			e := numgo.ErrArray[complex128](numgo.NoConvergence, "Eig() did not converge.  Stack index: %v", s.index(i))
+			return e, e
+		}
+
+		vm := make([]complex128, n*n)
+		for c := 0; c < n; c++ {
+			wd = append(wd, complex(re[c], im[c]))
+			for r := 0; r < n; r++ {
+				switch {
+				case im[c] > 0:
+					vm[r*n+c] = complex(vr[r*n+c], vr[r*n+c+1])
+				case im[c] < 0:
+					vm[r*n+c] = complex(vr[r*n+c-1], -vr[r*n+c])
+				default:
+					vm[r*n+c] = complex(vr[r*n+c], 0)
+				}
+			}
+		}
+
+		for c := 0; c < n; c++ {
+			var norm float64
+			for r := 0; r < n; r++ {
+				norm = math.Hypot(norm, math.Hypot(real(vm[r*n+c]), imag(vm[r*n+c])))
+			}
+			for r := 0; r < n && norm != 0; r++ {
+				vm[r*n+c] /= complex(norm, 0)
+			}
+		}
+		vd = append(vd, vm...)
+	}
+	return numgo.NewArray(wd).Reshape(shape(s.batch, n)...), numgo.NewArray(vd).Reshape(shape(s.batch, n, n)...)
+}
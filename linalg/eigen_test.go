@@ -0,0 +1,167 @@
+package linalg
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/Kunde21/numgo"
+	"github.com/Kunde21/numgo/internal/mat"
+)
+
+func TestEigh(t *testing.T) {
+	b := numgo.RandArray64(-1, 2, 6, 6)
+	for i, v := range []struct {
+		a  *numgo.Array64
+		ws []int
+		w  []float64
+	}{
+		{numgo.NewArray64([]float64{2, 1, 1, 2}, 2, 2), []int{2}, []float64{1, 3}},
+		{numgo.NewArray64([]float64{2, 99, 1, 2}, 2, 2), []int{2}, []float64{1, 3}},
+		{numgo.NewArray64([]float64{5, 0, 0, 0, -1, 0, 0, 0, 2}, 3, 3), []int{3}, []float64{-1, 2, 5}},
+		{numgo.NewArray64(nil, 3, 3), []int{3}, []float64{0, 0, 0}},
+		{numgo.NewArray64([]float64{2, 1, 1, 2, 0, 1, 1, 0}, 2, 2, 2), []int{2, 2}, []float64{1, 3, -1, 1}},
+		{b.Add(b.T()), []int{6}, nil},
+		{numgo.FullArray64(1, 4, 4), []int{4}, []float64{0, 0, 0, 4}},
+	} {
+		w, vec := Eigh(v.a)
+		if e := w.GetErr(); e != nil || vec.HasErr() {
+			t.Log("Test", i, "Unexpected error", e)
+			t.Fail()
+			continue
+		}
+		if !same(toF(w.Shape()), toF(v.ws)) || (v.w != nil && !same(w.Values(), v.w)) {
+			t.Log("Test", i, "Expected", v.w, "Got", w)
+			t.Fail()
+			continue
+		}
+
+		sh := v.a.Shape()
+		n := sh[len(sh)-1]
+		ad, wd, vd := v.a.Values(), w.Values(), vec.Values()
+		for m := 0; m < len(ad)/(n*n); m++ {
+			am, wm, vm := nth(ad, m, n), wd[m*n:(m+1)*n], nth(vd, m, n)
+			for r := 0; r < n; r++ {
+				for c := r + 1; c < n; c++ {
+					am[r*n+c] = am[c*n+r]
+				}
+			}
+
+			vw := make([]float64, n*n)
+			for r := 0; r < n; r++ {
+				for c := 0; c < n; c++ {
+					vw[r*n+c] = vm[r*n+c] * wm[c]
+				}
+			}
+			if !same(mul(am, vm, n, n, n), vw) {
+				t.Log("Test", i, "a·v != v·diag(w)", w, vec)
+				t.Fail()
+			}
+			if !same(mul(mat.Transpose(vm, n, n), vm, n, n, n), eye(n)) {
+				t.Log("Test", i, "v is not orthonormal", vec)
+				t.Fail()
+			}
+			for c := 1; c < n; c++ {
+				if wm[c] < wm[c-1] {
+					t.Log("Test", i, "Eigenvalues not in increasing order", w)
+					t.Fail()
+				}
+			}
+		}
+	}
+
+	if w, v := Eigh(numgo.NewArray64(nil, 2, 3)); w.GetErr() != numgo.ShapeError || !v.HasErr() {
+		t.Log("Expected ShapeError Got", w, v)
+		t.Fail()
+	}
+}
+
+func TestEig(t *testing.T) {
+	for i, v := range []struct {
+		a *numgo.Array64
+		w []complex128
+	}{
+		{numgo.NewArray64([]float64{2, 0, 0, 3}, 2, 2), []complex128{2, 3}},
+		{numgo.NewArray64([]float64{0, -1, 1, 0}, 2, 2), []complex128{1i, -1i}},
+		{numgo.NewArray64([]float64{1, 2, 0, 3}, 2, 2), []complex128{1, 3}},
+		{numgo.NewArray64([]float64{0, 0, 1, 1, 0, 0, 0, 1, 0}, 3, 3), []complex128{1, complex(-0.5, math.Sqrt(3)/2), complex(-0.5, -math.Sqrt(3)/2)}},
+		{numgo.NewArray64([]float64{1, 1, 0, 1}, 2, 2), []complex128{1, 1}},
+		{numgo.NewArray64(nil, 3, 3), []complex128{0, 0, 0}},
+		{numgo.RandArray64(-1, 2, 7, 7), nil},
+		{numgo.RandArray64(-1, 2, 3, 4, 4), nil},
+	} {
+		w, vec := Eig(v.a)
+		if e := w.GetErr(); e != nil || vec.HasErr() {
+			t.Log("Test", i, "Unexpected error", e)
+			t.Fail()
+			continue
+		}
+
+		sh := v.a.Shape()
+		n := sh[len(sh)-1]
+		ad, wd, vd := v.a.Values(), w.Values(), vec.Values()
+		if v.w != nil && !sameSet(wd, v.w) {
+			t.Log("Test", i, "Expected eigenvalues", v.w, "Got", w)
+			t.Fail()
+		}
+
+		for m := 0; m < len(ad)/(n*n); m++ {
+			am, wm, vm := nth(ad, m, n), wd[m*n:(m+1)*n], vd[m*n*n:(m+1)*n*n]
+			for c := 0; c < n; c++ {
+				var norm float64
+				for r := 0; r < n; r++ {
+					var av complex128
+					for k := 0; k < n; k++ {
+						av += complex(am[r*n+k], 0) * vm[k*n+c]
+					}
+					if cmplx.Abs(av-wm[c]*vm[r*n+c]) > 1e-9 {
+						t.Log("Test", i, "a·v != w·v for eigenvalue", wm[c], vec)
+						t.Fail()
+						break
+					}
+					norm += real(vm[r*n+c])*real(vm[r*n+c]) + imag(vm[r*n+c])*imag(vm[r*n+c])
+				}
+				if math.Abs(norm-1) > 1e-9 {
+					t.Log("Test", i, "Eigenvector", c, "not normalized", vec)
+					t.Fail()
+				}
+			}
+		}
+	}
+
+	for i, v := range []struct {
+		a   *numgo.Array64
+		err error
+	}{
+		{numgo.NewArray64(nil, 2, 3), numgo.ShapeError},
+		{numgo.NewArray64(nil, 4), numgo.ShapeError},
+		{numgo.NewArray64(nil, 2, 2).Reshape(3), numgo.ReshapeError},
+		{nil, numgo.NilError},
+	} {
+		if w, vec := Eig(v.a); w.GetErr() != v.err || !vec.HasErr() {
+			t.Log("Test", i, "Expected error", v.err, "Got", w, vec)
+			t.Fail()
+		}
+	}
+}
+
+// sameSet tests that two slices hold the same complex values in any order, within a small tolerance.
+func sameSet(a, b []complex128) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	used := make([]bool, len(b))
+	for _, x := range a {
+		found := false
+		for j, y := range b {
+			if !used[j] && cmplx.Abs(x-y) < 1e-9 {
+				used[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,291 @@
+// Package linalg provides linear algebra routines for numgo Array64 objects.
+//
+// Every function operates on the last two axes of its arguments, so an array with more
+// than two axes is treated as a stack of matrices.  The leading axes of all arguments must match,
+// and the same leading axes are kept in the results.
+//
+// Errors are reported through the Array64 error chain, the same as numgo methods.  Errors on
+// an argument are passed on to the result, and new errors, such as a ShapeError for mismatched
+// arguments or a SingularMatrix error from Solve() or Inv(), are returned in an empty array.
+package linalg
+
+import (
+	"math"
+
+	"github.com/Kunde21/numgo"
+)
+
+// stack holds a copy of the matrices stored in the last two axes of an array.
+type stack struct {
+	batch []int
+	m, n  int
+	data  []float64
+}
+
+// count returns the number of matrices in the stack.
+func (s stack) count() int {
+	c := 1
+	for _, v := range s.batch {
+		c *= v
+	}
+	return c
+}
+
+// mat returns the i-th matrix of the stack in row-major order.
+func (s stack) mat(i int) []float64 {
+	return s.data[i*s.m*s.n : (i+1)*s.m*s.n]
+}
+
+// index converts the position of a matrix in the stack to its index on the leading axes.
+func (s stack) index(i int) []int {
+	idx := make([]int, len(s.batch))
+	for j := len(s.batch) - 1; j >= 0; j-- {
+		idx[j], i = i%s.batch[j], i/s.batch[j]
+	}
+	return idx
+}
+
+// check returns the error array of an argument that holds an error, with nil pointers reported as a NilError.
+func check(a *numgo.Array64, mthd string) *numgo.Array64 {
+	if a == nil {
+		return numgo.ErrArray[float64](numgo.NilError, "Nil pointer received by %s().", mthd)
+	}
+	return a
+}
+
+// matrices reads the stack of matrices in a.  A non-nil error array is returned when a
+// holds an error or has fewer than two axes.
+func matrices(a *numgo.Array64, mthd string) (s stack, err *numgo.Array64) {
+	if a.HasErr() {
+		return s, check(a, mthd)
+	}
+
+	sh := a.Shape()
+	if len(sh) < 2 {
+		return s, numgo.ErrArray[float64](numgo.ShapeError, "Array with shape %v received by %s() is not a matrix or stack of matrices.", sh, mthd)
+	}
+	s.batch, s.m, s.n = sh[:len(sh)-2], sh[len(sh)-2], sh[len(sh)-1]
+	s.data = a.Values()
+	return s, nil
+}
+
+// square reads a stack of square matrices.
+func square(a *numgo.Array64, mthd string) (s stack, err *numgo.Array64) {
+	if s, err = matrices(a, mthd); err == nil && s.m != s.n {
+		err = numgo.ErrArray[float64](numgo.ShapeError, "Non-square matrix with shape %v received by %s().", a.Shape(), mthd)
+	}
+	return s, err
+}
+
+// rhs reads the right hand side b of a system of equations with the matrices in s.
+// b holds either a vector or a matrix for each matrix in s, and vec reports which.
+// Vectors are read as single column matrices.
+func rhs(s stack, b *numgo.Array64, mthd string) (r stack, vec bool, err *numgo.Array64) {
+	if b.HasErr() {
+		return r, false, check(b, mthd)
+	}
+
+	sh, nb := b.Shape(), len(s.batch)
+	match := len(sh) > nb && len(sh) <= nb+2 && sh[nb] == s.m
+	for i := 0; match && i < nb; i++ {
+		match = sh[i] == s.batch[i]
+	}
+	if !match {
+		return r, false, numgo.ErrArray[float64](numgo.ShapeError, "Shapes received by %s() do not match.  Matrix stack: %v  Right hand side: %v", mthd, shape(s.batch, s.m, s.n), sh)
+	}
+
+	r = stack{batch: s.batch, m: s.m, n: 1, data: b.Values()}
+	if vec = len(sh) == nb+1; !vec {
+		r.n = sh[nb+1]
+	}
+	return r, vec, nil
+}
+
+// shape creates the shape of a result from the leading axes of a stack and the trailing axes in tail.
+// A result with no axes is returned as a single element.
+func shape(batch []int, tail ...int) []int {
+	sh := append(append(make([]int, 0, len(batch)+len(tail)), batch...), tail...)
+	if len(sh) == 0 {
+		return []int{1}
+	}
+	return sh
+}
+
+// singular creates the SingularMatrix error for the i-th matrix of the stack.
+func singular(s stack, i int, mthd string) *numgo.Array64 {
+	return numgo.ErrArray[float64](numgo.SingularMatrix, "Singular matrix received by %s().  Stack index: %v", mthd, s.index(i))
+}
+
+// factorLU computes the LU factorization with partial pivoting of the n×n matrix d, in place.
+// L is stored below the diagonal, with an implicit unit diagonal, and U on and above it.
+// Row i of the factorization is row piv[i] of the original matrix, and sign is the sign
+// of that permutation.  ok is false when the matrix is singular.
+func factorLU(d []float64, n int) (piv []int, sign float64, ok bool) {
+	piv, sign, ok = make([]int, n), 1, true
+	for i := range piv {
+		piv[i] = i
+	}
+
+	for j := 0; j < n; j++ {
+		p := j
+		for i := j + 1; i < n; i++ {
+			if math.Abs(d[i*n+j]) > math.Abs(d[p*n+j]) {
+				p = i
+			}
+		}
+		if p != j {
+			for c := 0; c < n; c++ {
+				d[j*n+c], d[p*n+c] = d[p*n+c], d[j*n+c]
+			}
+			piv[j], piv[p] = piv[p], piv[j]
+			sign = -sign
+		}
+
+		if d[j*n+j] == 0 {
+			ok = false
+			continue
+		}
+		for i := j + 1; i < n; i++ {
+			l := d[i*n+j] / d[j*n+j]
+			d[i*n+j] = l
+			for c := j + 1; c < n; c++ {
+				d[i*n+c] -= l * d[j*n+c]
+			}
+		}
+	}
+	return piv, sign, ok
+}
+
+// solveLU solves the system with the LU factorization of an n×n matrix for the n×k matrix b,
+// and stores the solution in x.
+func solveLU(lu []float64, piv []int, n int, b []float64, k int, x []float64) {
+	for i, p := range piv {
+		copy(x[i*k:(i+1)*k], b[p*k:(p+1)*k])
+	}
+
+	for c := 0; c < k; c++ {
+		for i := 1; i < n; i++ {
+			for j := 0; j < i; j++ {
+				x[i*k+c] -= lu[i*n+j] * x[j*k+c]
+			}
+		}
+		for i := n - 1; i >= 0; i-- {
+			for j := i + 1; j < n; j++ {
+				x[i*k+c] -= lu[i*n+j] * x[j*k+c]
+			}
+			x[i*k+c] /= lu[i*n+i]
+		}
+	}
+}
+
+// solve solves the systems of equations of the matrices in s with the right hand sides in r.
+func solve(s, r stack, mthd string) (x []float64, err *numgo.Array64) {
+	x = make([]float64, len(r.data))
+	for i, sz := 0, s.n*r.n; i < s.count(); i++ {
+		lu := s.mat(i)
+		piv, _, ok := factorLU(lu, s.n)
+		if !ok {
+			return nil, singular(s, i, mthd)
+		}
+		solveLU(lu, piv, s.n, r.mat(i), r.n, x[i*sz:(i+1)*sz])
+	}
+	return x, nil
+}
+
+// Solve solves the linear system a·x = b for x.
+//
+// a holds square matrices, and b holds either one vector or one matrix for each matrix in a.
+// The system is solved with an LU factorization with partial pivoting, and
+// a SingularMatrix error is returned if any matrix in a is singular.
+func Solve(a, b *numgo.Array64) *numgo.Array64 {
+	s, err := square(a, "Solve")
+	if err != nil {
+		return err
+	}
+	r, vec, err := rhs(s, b, "Solve")
+	if err != nil {
+		return err
+	}
+
+	x, err := solve(s, r, "Solve")
+	if err != nil {
+		return err
+	}
+	if vec {
+		return numgo.NewArray64(x).Reshape(shape(s.batch, s.n)...)
+	}
+	return numgo.NewArray64(x).Reshape(shape(s.batch, s.n, r.n)...)
+}
+
+// Inv calculates the inverse of each square matrix in a.
+// A SingularMatrix error is returned if any matrix in a is singular.
+func Inv(a *numgo.Array64) *numgo.Array64 {
+	s, err := square(a, "Inv")
+	if err != nil {
+		return err
+	}
+
+	r := stack{batch: s.batch, m: s.n, n: s.n, data: make([]float64, len(s.data))}
+	for i := 0; i < s.count(); i++ {
+		id := r.mat(i)
+		for j := 0; j < s.n; j++ {
+			id[j*s.n+j] = 1
+		}
+	}
+
+	x, err := solve(s, r, "Inv")
+	if err != nil {
+		return err
+	}
+	return numgo.NewArray64(x).Reshape(shape(s.batch, s.n, s.n)...)
+}
+
+// Det calculates the determinant of each square matrix in a.
+//
+// The result has the leading axes of a, or a single element when a is one matrix.
+func Det(a *numgo.Array64) *numgo.Array64 {
+	s, err := square(a, "Det")
+	if err != nil {
+		return err
+	}
+
+	det := make([]float64, s.count())
+	for i := range det {
+		lu := s.mat(i)
+		_, sign, _ := factorLU(lu, s.n)
+		det[i] = sign
+		for j := 0; j < s.n; j++ {
+			det[i] *= lu[j*s.n+j]
+		}
+	}
+	return numgo.NewArray64(det).Reshape(shape(s.batch)...)
+}
+
+// SlogDet calculates the sign and the natural logarithm of the absolute value of the
+// determinant of each square matrix in a.  This avoids the overflow and underflow
+// Det() is prone to with large matrices.
+//
+// The sign is 1, -1 or 0.  Singular matrices have a sign of 0 and a logdet of -Inf.
+func SlogDet(a *numgo.Array64) (sign, logdet *numgo.Array64) {
+	s, err := square(a, "SlogDet")
+	if err != nil {
+		return err, err
+	}
+
+	sg, ld := make([]float64, s.count()), make([]float64, s.count())
+	for i := range sg {
+		lu := s.mat(i)
+		_, sg[i], _ = factorLU(lu, s.n)
+		for j := 0; j < s.n; j++ {
+			u := lu[j*s.n+j]
+			if u < 0 {
+				sg[i] = -sg[i]
+			}
+			ld[i] += math.Log(math.Abs(u))
+		}
+		if math.IsInf(ld[i], -1) {
+			sg[i] = 0
+		}
+	}
+	return numgo.NewArray64(sg).Reshape(shape(s.batch)...), numgo.NewArray64(ld).Reshape(shape(s.batch)...)
+}
@@ -0,0 +1,227 @@
+package linalg
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Kunde21/numgo"
+)
+
+func init() {
+	numgo.Debug(true)
+}
+
+// near tests that a has the expected shape and values, within a small tolerance.
+func near(a *numgo.Array64, shape []int, vals []float64) bool {
+	if a.HasErr() {
+		return false
+	}
+	sh, v := a.Shape(), a.Values()
+	if len(sh) != len(shape) || len(v) != len(vals) {
+		return false
+	}
+	for i := range sh {
+		if sh[i] != shape[i] {
+			return false
+		}
+	}
+	for i := range v {
+		if math.Abs(v[i]-vals[i]) > 1e-9*math.Max(1, math.Abs(vals[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSolve(t *testing.T) {
+	a := numgo.NewArray64([]float64{3, 1, 1, 2}, 2, 2)
+	st := numgo.NewArray64([]float64{3, 1, 1, 2, 0, 1, 1, 0}, 2, 2, 2)
+	for i, v := range []struct {
+		a, b  *numgo.Array64
+		shape []int
+		res   []float64
+	}{
+		{a, numgo.NewArray64([]float64{9, 8}), []int{2}, []float64{2, 3}},
+		{a, numgo.NewArray64([]float64{9, 1, 8, 2}, 2, 2), []int{2, 2}, []float64{2, 0, 3, 1}},
+		{numgo.NewArray64([]float64{3, 1, 2, 2}, 2, 2).T(), numgo.NewArray64([]float64{8, 4}), []int{2}, []float64{2, 1}},
+		{st, numgo.NewArray64([]float64{9, 8, 4, 5}, 2, 2), []int{2, 2}, []float64{2, 3, 5, 4}},
+		{st, numgo.NewArray64([]float64{9, 8, 4, 5}, 2, 2, 1), []int{2, 2, 1}, []float64{2, 3, 5, 4}},
+		{numgo.NewArray64([]float64{0, 0, 1, 0, 2, 0, 3, 0, 0}, 3, 3), numgo.NewArray64([]float64{1, 4, 6}), []int{3}, []float64{2, 2, 1}},
+	} {
+		if r := Solve(v.a, v.b); !near(r, v.shape, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+
+	for i, v := range []struct {
+		a, b *numgo.Array64
+		err  error
+	}{
+		{numgo.NewArray64([]float64{1, 2, 2, 4}, 2, 2), numgo.NewArray64([]float64{1, 2}), numgo.SingularMatrix},
+		{numgo.NewArray64([]float64{1, 0, 0, 1, 0, 0, 0, 0}, 2, 2, 2), numgo.NewArray64(nil, 2, 2), numgo.SingularMatrix},
+		{numgo.NewArray64(nil, 2, 3), numgo.NewArray64(nil, 2), numgo.ShapeError},
+		{numgo.NewArray64(nil, 2), numgo.NewArray64(nil, 2), numgo.ShapeError},
+		{a, numgo.NewArray64(nil, 3), numgo.ShapeError},
+		{a, numgo.NewArray64(nil, 2, 2, 2), numgo.ShapeError},
+		{st, numgo.NewArray64(nil, 3, 2), numgo.ShapeError},
+		{numgo.NewArray64(nil, -1), a, numgo.NegativeAxis},
+		{a, a.C().Reshape(3), numgo.ReshapeError},
+		{nil, a, numgo.NilError},
+	} {
+		if e := Solve(v.a, v.b).GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+		}
+	}
+
+	_, d, _ := Solve(numgo.NewArray64([]float64{1, 0, 0, 1, 0, 0, 0, 0}, 2, 2, 2), numgo.NewArray64(nil, 2, 2)).GetDebug()
+	if d != "Singular matrix received by Solve().  Stack index: [1]" {
+		t.Log("Incorrect debug string:", d)
+		t.Fail()
+	}
+}
+
+func TestInv(t *testing.T) {
+	for i, v := range []struct {
+		a     *numgo.Array64
+		shape []int
+		res   []float64
+		err   error
+	}{
+		{numgo.NewArray64([]float64{4, 7, 2, 6}, 2, 2), []int{2, 2}, []float64{0.6, -0.7, -0.2, 0.4}, nil},
+		{numgo.Identity(3).MultC(2), []int{3, 3}, []float64{0.5, 0, 0, 0, 0.5, 0, 0, 0, 0.5}, nil},
+		{numgo.NewArray64([]float64{4, 7, 2, 6, 0, 1, 1, 0}, 2, 2, 2), []int{2, 2, 2}, []float64{0.6, -0.7, -0.2, 0.4, 0, 1, 1, 0}, nil},
+		{numgo.NewArray64(nil, 2, 2), nil, nil, numgo.SingularMatrix},
+		{numgo.NewArray64(nil, 2, 1), nil, nil, numgo.ShapeError},
+	} {
+		r := Inv(v.a)
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !near(r, v.shape, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+
+	a := numgo.RandArray64(-1, 2, 5, 5).Add(numgo.Identity(5).MultC(5))
+	if r := a.MatProd(Inv(a)); !near(r, []int{5, 5}, numgo.Identity(5).Values()) {
+		t.Log("a·Inv(a) Expected identity Got", r)
+		t.Fail()
+	}
+}
+
+func TestDet(t *testing.T) {
+	for i, v := range []struct {
+		a      *numgo.Array64
+		shape  []int
+		det    []float64
+		sign   []float64
+		logdet []float64
+	}{
+		{numgo.NewArray64([]float64{1, 2, 3, 4}, 2, 2), []int{1}, []float64{-2}, []float64{-1}, []float64{math.Ln2}},
+		{numgo.Identity(4), []int{1}, []float64{1}, []float64{1}, []float64{0}},
+		{numgo.NewArray64([]float64{0, 0, 2, 0, 3, 0, 1, 0, 0}, 3, 3), []int{1}, []float64{-6}, []float64{-1}, []float64{math.Log(6)}},
+		{numgo.NewArray64([]float64{1, 2, 2, 4, 2, 0, 0, 3, 0, 1, -1, 0}, 3, 2, 2), []int{3}, []float64{0, 6, 1}, []float64{0, 1, 1}, []float64{math.Inf(-1), math.Log(6), 0}},
+		{numgo.Identity(2).MultC(1e200).Reshape(1, 2, 2), []int{1}, []float64{math.Inf(1)}, []float64{1}, []float64{400 * math.Log(10)}},
+	} {
+		if r := Det(v.a); !near(r, v.shape, v.det) {
+			t.Log("Test", i, "Det Expected", v.det, "Got", r)
+			t.Fail()
+		}
+		s, l := SlogDet(v.a)
+		if !near(s, v.shape, v.sign) || !near(l, v.shape, v.logdet) {
+			t.Log("Test", i, "SlogDet Expected", v.sign, v.logdet, "Got", s, l)
+			t.Fail()
+		}
+	}
+
+	if e := Det(numgo.NewArray64(nil, 2, 3)).GetErr(); e != numgo.ShapeError {
+		t.Log("Det Expected ShapeError Got", e)
+		t.Fail()
+	}
+	if s, l := SlogDet(numgo.NewArray64(nil, 3)); s.GetErr() != numgo.ShapeError || !l.HasErr() {
+		t.Log("SlogDet Expected ShapeError Got", s, l)
+		t.Fail()
+	}
+}
+
+func TestLstSq(t *testing.T) {
+	for i, v := range []struct {
+		a, b         *numgo.Array64
+		xs, rs, ks   []int
+		x, res, rank []float64
+	}{
+		{
+			numgo.NewArray64([]float64{1, 0, 1, 1, 1, 2}, 3, 2), numgo.NewArray64([]float64{1, 2, 2}),
+			[]int{2}, []int{1}, []int{1}, []float64{7.0 / 6, 0.5}, []float64{1.0 / 6}, []float64{2},
+		},
+		{
+			numgo.NewArray64([]float64{1, 1, 1, 1}, 2, 2), numgo.NewArray64([]float64{2, 2}),
+			[]int{2}, []int{1}, []int{1}, []float64{1, 1}, []float64{0}, []float64{1},
+		},
+		{
+			numgo.NewArray64([]float64{1, 2}, 1, 2), numgo.NewArray64([]float64{5}),
+			[]int{2}, []int{1}, []int{1}, []float64{1, 2}, []float64{0}, []float64{1},
+		},
+		{
+			numgo.NewArray64([]float64{1, 2, 2, 4, 3, 6}, 3, 2), numgo.NewArray64([]float64{5, 1, 10, 1, 15, 1}, 3, 2),
+			[]int{2, 2}, []int{2}, []int{1}, []float64{1, 3.0 / 35, 2, 6.0 / 35}, []float64{0, 3.0 / 7}, []float64{1},
+		},
+		{
+			numgo.NewArray64([]float64{3, 1, 1, 2, 1, 0, 0, 0}, 2, 2, 2), numgo.NewArray64([]float64{9, 8, 4, 5}, 2, 2),
+			[]int{2, 2}, []int{2}, []int{2}, []float64{2, 3, 4, 0}, []float64{0, 25}, []float64{2, 1},
+		},
+		{
+			numgo.NewArray64(nil, 2, 2), numgo.NewArray64([]float64{1, 1}),
+			[]int{2}, []int{1}, []int{1}, []float64{0, 0}, []float64{2}, []float64{0},
+		},
+	} {
+		x, res, rank := LstSq(v.a, v.b)
+		if !near(x, v.xs, v.x) || !near(res, v.rs, v.res) || !near(rank, v.ks, v.rank) {
+			t.Log("Test", i, "Expected", v.x, v.res, v.rank, "Got", x, res, rank)
+			t.Fail()
+		}
+	}
+
+	a := numgo.RandArray64(-1, 2, 6, 4)
+	b := a.MatProd(numgo.NewArray64([]float64{1, -2, 3, 0.5}, 4, 1))
+	if x, res, _ := LstSq(a, b); !near(x, []int{4, 1}, []float64{1, -2, 3, 0.5}) || res.At(0) > 1e-20 {
+		t.Log("Expected exact solution [1 -2 3 0.5] Got", x, res)
+		t.Fail()
+	}
+
+	if x, res, rank := LstSq(numgo.NewArray64(nil, 3, 2), numgo.NewArray64(nil, 2)); x.GetErr() != numgo.ShapeError || !res.HasErr() || !rank.HasErr() {
+		t.Log("Expected ShapeError Got", x, res, rank)
+		t.Fail()
+	}
+}
+
+func TestMatrixRank(t *testing.T) {
+	for i, v := range []struct {
+		a     *numgo.Array64
+		shape []int
+		rank  []float64
+	}{
+		{numgo.Identity(4), []int{1}, []float64{4}},
+		{numgo.FullArray64(1, 3, 3), []int{1}, []float64{1}},
+		{numgo.NewArray64(nil, 3, 2), []int{1}, []float64{0}},
+		{numgo.NewArray64([]float64{1, 2, 3, 2, 4, 6, 1, 0, 1}, 3, 3), []int{1}, []float64{2}},
+		{numgo.NewArray64([]float64{1, 2, 3, 4, 5, 6}, 2, 3), []int{1}, []float64{2}},
+		{numgo.NewArray64([]float64{1, 2, 1e-20, 2e-20, 0, 0, 1, 0}, 2, 2, 2), []int{2}, []float64{1, 1}},
+		{numgo.NewArray64([]float64{1, 1, 1, 1 + 1e-10}, 2, 2), []int{1}, []float64{2}},
+	} {
+		if r := MatrixRank(v.a); !near(r, v.shape, v.rank) {
+			t.Log("Test", i, "Expected", v.rank, "Got", r)
+			t.Fail()
+		}
+	}
+
+	if e := MatrixRank(numgo.NewArray64(nil, 4)).GetErr(); e != numgo.ShapeError {
+		t.Log("Expected ShapeError Got", e)
+		t.Fail()
+	}
+}
@@ -0,0 +1,216 @@
+package linalg
+
+import (
+	"math"
+
+	"github.com/Kunde21/numgo"
+	"github.com/Kunde21/numgo/internal/mat"
+)
+
+// eps is the difference between 1 and the next larger float64 value.
+const eps = mat.Eps
+
+// factorQR computes the Householder QR factorization of the m×n matrix d, in place.
+// R is stored on and above the diagonal, and the Householder vectors below it with an
+// implicit unit first element.  tau holds the scaling factor of each reflection.
+//
+// When pivot is set, the column with the largest remaining norm is moved forward at each step,
+// which orders the diagonal of R by decreasing magnitude.  Column j of the factorization is
+// column perm[j] of the original matrix.
+func factorQR(d []float64, m, n int, pivot bool) (tau []float64, perm []int) {
+	k := m
+	if n < k {
+		k = n
+	}
+	tau, perm = make([]float64, k), make([]int, n)
+	for j := range perm {
+		perm[j] = j
+	}
+
+	for j := 0; j < k; j++ {
+		if pivot {
+			p, max := j, -1.0
+			for c := j; c < n; c++ {
+				var s float64
+				for i := j; i < m; i++ {
+					s += d[i*n+c] * d[i*n+c]
+				}
+				if s > max {
+					p, max = c, s
+				}
+			}
+			if p != j {
+				for i := 0; i < m; i++ {
+					d[i*n+j], d[i*n+p] = d[i*n+p], d[i*n+j]
+				}
+				perm[j], perm[p] = perm[p], perm[j]
+			}
+		}
+
+		var norm float64
+		for i := j; i < m; i++ {
+			norm = math.Hypot(norm, d[i*n+j])
+		}
+		if norm == 0 {
+			continue
+		}
+
+		x0 := d[j*n+j]
+		beta := -math.Copysign(norm, x0)
+		tau[j] = (beta - x0) / beta
+		for i, sc := j+1, 1/(x0-beta); i < m; i++ {
+			d[i*n+j] *= sc
+		}
+		d[j*n+j] = beta
+
+		for c := j + 1; c < n; c++ {
+			s := d[j*n+c]
+			for i := j + 1; i < m; i++ {
+				s += d[i*n+j] * d[i*n+c]
+			}
+			s *= tau[j]
+			d[j*n+c] -= s
+			for i := j + 1; i < m; i++ {
+				d[i*n+c] -= s * d[i*n+j]
+			}
+		}
+	}
+	return tau, perm
+}
+
+// reflect applies the j-th Householder reflection of a factorQR result to the m×k matrix b.
+func reflect(qr, tau []float64, n, j int, b []float64, m, k int) {
+	for c := 0; c < k; c++ {
+		s := b[j*k+c]
+		for i := j + 1; i < m; i++ {
+			s += qr[i*n+j] * b[i*k+c]
+		}
+		s *= tau[j]
+		b[j*k+c] -= s
+		for i := j + 1; i < m; i++ {
+			b[i*k+c] -= s * qr[i*n+j]
+		}
+	}
+}
+
+// rank counts the diagonal elements of the pivoted R factor of an m×n matrix that are
+// significant, relative to the largest one.
+func rank(qr []float64, m, n int) (r int) {
+	k, mx := m, n
+	if n < m {
+		k, mx = n, m
+	}
+	if k == 0 {
+		return 0
+	}
+
+	tol := math.Abs(qr[0]) * float64(mx) * eps
+	for r < k && math.Abs(qr[r*n+r]) > tol {
+		r++
+	}
+	return r
+}
+
+// lstsq calculates the least squares solution x of the m×n system a·x = b for the m×k matrix b.
+func lstsq(a []float64, m, n int, b []float64, k int) (x []float64, r int) {
+	qr := append([]float64(nil), a...)
+	tau, perm := factorQR(qr, m, n, true)
+	c := append([]float64(nil), b...)
+	for j := range tau {
+		reflect(qr, tau, n, j, c, m, k)
+	}
+	r = rank(qr, m, n)
+
+	// Find the minimum norm solution y of the r×n trapezoidal system R·y = c by factoring
+	// the transpose of R as Q2·R2, so R·y = R2ᵀ·Q2ᵀ·y = c.
+	t := make([]float64, n*r)
+	for i := 0; i < r; i++ {
+		for j := i; j < n; j++ {
+			t[j*r+i] = qr[i*n+j]
+		}
+	}
+	tau2, _ := factorQR(t, n, r, false)
+
+	y := make([]float64, n*k)
+	for col := 0; col < k; col++ {
+		for i := 0; i < r; i++ {
+			s := c[i*k+col]
+			for j := 0; j < i; j++ {
+				s -= t[j*r+i] * y[j*k+col]
+			}
+			y[i*k+col] = s / t[i*r+i]
+		}
+	}
+	for j := len(tau2) - 1; j >= 0; j-- {
+		reflect(t, tau2, r, j, y, n, k)
+	}
+
+	x = make([]float64, n*k)
+	for j, p := range perm {
+		copy(x[p*k:(p+1)*k], y[j*k:(j+1)*k])
+	}
+	return x, r
+}
+
+// LstSq calculates the least squares solution x of the linear system a·x = b,
+// minimizing the Euclidean norm of b - a·x.
+//
+// a holds m×n matrices, and b holds either one vector or one matrix for each matrix in a.
+// The solution is found with a QR factorization with column pivoting.  When a matrix doesn't
+// have full column rank, the solution with the smallest norm is returned.
+//
+// res holds the sum of squared residuals of each column of b, and rank holds the
+// effective rank of each matrix in a.
+func LstSq(a, b *numgo.Array64) (x, res, rank *numgo.Array64) {
+	s, err := matrices(a, "LstSq")
+	if err != nil {
+		return err, err, err
+	}
+	r, vec, err := rhs(s, b, "LstSq")
+	if err != nil {
+		return err, err, err
+	}
+
+	xd, rd := make([]float64, 0, s.count()*s.n*r.n), make([]float64, s.count())
+	sq := make([]float64, s.count()*r.n)
+	for i := range rd {
+		ma, mb := s.mat(i), r.mat(i)
+		sol, rk := lstsq(ma, s.m, s.n, mb, r.n)
+		xd, rd[i] = append(xd, sol...), float64(rk)
+
+		for c := 0; c < r.n; c++ {
+			for j := 0; j < s.m; j++ {
+				v := mb[j*r.n+c]
+				for l := 0; l < s.n; l++ {
+					v -= ma[j*s.n+l] * sol[l*r.n+c]
+				}
+				sq[i*r.n+c] += v * v
+			}
+		}
+	}
+
+	rank = numgo.NewArray64(rd).Reshape(shape(s.batch)...)
+	if vec {
+		return numgo.NewArray64(xd).Reshape(shape(s.batch, s.n)...), numgo.NewArray64(sq).Reshape(shape(s.batch)...), rank
+	}
+	return numgo.NewArray64(xd).Reshape(shape(s.batch, s.n, r.n)...), numgo.NewArray64(sq).Reshape(shape(s.batch, r.n)...), rank
+}
+
+// MatrixRank calculates the rank of each matrix in a, using a QR factorization with column pivoting.
+//
+// Diagonal elements of R smaller than max(m, n)·eps times the largest one are treated as zero.
+// The result has the leading axes of a, or a single element when a is one matrix.
+func MatrixRank(a *numgo.Array64) *numgo.Array64 {
+	s, err := matrices(a, "MatrixRank")
+	if err != nil {
+		return err
+	}
+
+	rd := make([]float64, s.count())
+	for i := range rd {
+		qr := s.mat(i)
+		factorQR(qr, s.m, s.n, true)
+		rd[i] = float64(rank(qr, s.m, s.n))
+	}
+	return numgo.NewArray64(rd).Reshape(shape(s.batch)...)
+}
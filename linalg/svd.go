@@ -0,0 +1,114 @@
+package linalg
+
+import (
+	"math"
+
+	"github.com/Kunde21/numgo"
+	"github.com/Kunde21/numgo/internal/mat"
+)
+
+// widen copies the m×n matrix d into the first columns of an m×cols matrix.
+func widen(d []float64, m, n, cols int) []float64 {
+	w := make([]float64, m*cols)
+	for r := 0; r < m; r++ {
+		copy(w[r*cols:r*cols+n], d[r*n:(r+1)*n])
+	}
+	return w
+}
+
+// orthonormalize makes the columns of the m×n matrix d orthonormal with the Gram-Schmidt process, in place.
+// Columns that are zero, or that lose most of their norm to the previous columns, are replaced by the
+// standard basis vector that is furthest from the span of the previous columns.
+func orthonormalize(d []float64, m, n int) {
+	col := make([]float64, m)
+	project := func(c int) float64 {
+		for pass := 0; pass < 2; pass++ {
+			for p := 0; p < c; p++ {
+				var dot float64
+				for r := 0; r < m; r++ {
+					dot += d[r*n+p] * col[r]
+				}
+				for r := 0; r < m; r++ {
+					col[r] -= dot * d[r*n+p]
+				}
+			}
+		}
+		var norm float64
+		for _, v := range col {
+			norm = math.Hypot(norm, v)
+		}
+		return norm
+	}
+
+	for c := 0; c < n; c++ {
+		var norm0 float64
+		for r := 0; r < m; r++ {
+			col[r] = d[r*n+c]
+			norm0 = math.Hypot(norm0, col[r])
+		}
+
+		norm := project(c)
+		if norm0 == 0 || norm < norm0/2 {
+			basis := func(e int) float64 {
+				for r := range col {
+					col[r] = 0
+				}
+				col[e] = 1
+				return project(c)
+			}
+			pick, best := 0, -1.0
+			for e := 0; e < m; e++ {
+				if nm := basis(e); nm > best {
+					pick, best = e, nm
+				}
+			}
+			norm = basis(pick)
+		}
+
+		for r := 0; r < m; r++ {
+			d[r*n+c] = col[r] / norm
+		}
+	}
+}
+
+// SVD calculates the singular value decomposition of each m×n matrix in a, such that
+// a = u·diag(s)·vt.  Singular values are returned in decreasing order, u has orthonormal
+// columns and vt has orthonormal rows.
+//
+// When full is set, u is m×m and vt is n×n.  Otherwise the reduced decomposition is returned,
+// where u is m×k and vt is k×n, with k = min(m, n).
+//
+// The decomposition is calculated with one-sided Jacobi rotations, and a NoConvergence error
+// is returned if they don't converge.
+func SVD(a *numgo.Array64, full bool) (u, s, vt *numgo.Array64) {
+	st, err := matrices(a, "SVD")
+	if err != nil {
+		return err, err, err
+	}
+
+	m, n, k := st.m, st.n, st.m
+	if n < k {
+		k = n
+	}
+	uc, vc := k, k
+	if full {
+		uc, vc = m, n
+	}
+
+	ud, sd, vd := make([]float64, 0, st.count()*m*uc), make([]float64, 0, st.count()*k), make([]float64, 0, st.count()*vc*n)
+	for i := 0; i < st.count(); i++ {
+		um, sm, vm, ok := mat.SVD(st.mat(i), m, n)
+		if !ok {
+			e := numgo.ErrArray[float64](numgo.NoConvergence, "SVD() did not converge.  Stack index: %v", st.index(i))
+			return e, e, e
+		}
+
+		um, vm = widen(um, m, k, uc), widen(vm, n, k, vc)
+		orthonormalize(um, m, uc)
+		orthonormalize(vm, n, vc)
+		ud, sd, vd = append(ud, um...), append(sd, sm...), append(vd, mat.Transpose(vm, n, vc)...)
+	}
+	return numgo.NewArray64(ud).Reshape(shape(st.batch, m, uc)...),
+		numgo.NewArray64(sd).Reshape(shape(st.batch, k)...),
+		numgo.NewArray64(vd).Reshape(shape(st.batch, vc, n)...)
+}
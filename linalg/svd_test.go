@@ -0,0 +1,84 @@
+package linalg
+
+import (
+	"testing"
+
+	"github.com/Kunde21/numgo"
+	"github.com/Kunde21/numgo/internal/mat"
+)
+
+func TestSVD(t *testing.T) {
+	for i, v := range []struct {
+		a          *numgo.Array64
+		full       bool
+		us, ss, vs []int
+		s          []float64
+	}{
+		{numgo.NewArray64([]float64{3, 0, 0, -2}, 2, 2), false, []int{2, 2}, []int{2}, []int{2, 2}, []float64{3, 2}},
+		{numgo.NewArray64([]float64{0, 2, 0, 0, 0, 0, 1, 0, 0}, 3, 3), false, []int{3, 3}, []int{3}, []int{3, 3}, []float64{2, 1, 0}},
+		{numgo.NewArray64([]float64{3, 2, 2, 2, 3, -2}, 2, 3), false, []int{2, 2}, []int{2}, []int{2, 3}, []float64{5, 3}},
+		{numgo.NewArray64([]float64{3, 2, 2, 2, 3, -2}, 2, 3), true, []int{2, 2}, []int{2}, []int{3, 3}, []float64{5, 3}},
+		{numgo.NewArray64([]float64{3, 2, 2, 3, 2, -2}, 3, 2), true, []int{3, 3}, []int{2}, []int{2, 2}, []float64{5, 3}},
+		{numgo.FullArray64(1, 4, 3), false, []int{4, 3}, []int{3}, []int{3, 3}, []float64{2 * 1.7320508075688772, 0, 0}},
+		{numgo.FullArray64(1, 4, 3), true, []int{4, 4}, []int{3}, []int{3, 3}, []float64{2 * 1.7320508075688772, 0, 0}},
+		{numgo.NewArray64(nil, 2, 3), true, []int{2, 2}, []int{2}, []int{3, 3}, []float64{0, 0}},
+		{numgo.RandArray64(-1, 2, 6, 4), false, []int{6, 4}, []int{4}, []int{4, 4}, nil},
+		{numgo.RandArray64(-1, 2, 4, 7), true, []int{4, 4}, []int{4}, []int{7, 7}, nil},
+		{numgo.RandArray64(-1, 2, 3, 2, 5, 3), false, []int{3, 2, 5, 3}, []int{3, 2, 3}, []int{3, 2, 3, 3}, nil},
+	} {
+		u, s, vt := SVD(v.a, v.full)
+		if e := u.GetErr(); e != nil || s.HasErr() || vt.HasErr() {
+			t.Log("Test", i, "Unexpected error", e)
+			t.Fail()
+			continue
+		}
+		if !same(toF(u.Shape()), toF(v.us)) || !same(toF(s.Shape()), toF(v.ss)) || !same(toF(vt.Shape()), toF(v.vs)) {
+			t.Log("Test", i, "Expected shapes", v.us, v.ss, v.vs, "Got", u.Shape(), s.Shape(), vt.Shape())
+			t.Fail()
+			continue
+		}
+		if v.s != nil && !same(s.Values(), v.s) {
+			t.Log("Test", i, "Expected singular values", v.s, "Got", s)
+			t.Fail()
+		}
+
+		sh := v.a.Shape()
+		m, n := sh[len(sh)-2], sh[len(sh)-1]
+		uc, k, vr := v.us[len(v.us)-1], v.ss[len(v.ss)-1], v.vs[len(v.vs)-2]
+		ad, ud, sd, vd := v.a.Values(), u.Values(), s.Values(), vt.Values()
+		for b := 0; b < len(ad)/(m*n); b++ {
+			um, sm, vm := ud[b*m*uc:(b+1)*m*uc], sd[b*k:(b+1)*k], vd[b*vr*n:(b+1)*vr*n]
+
+			// u·diag(s)·vt with the first k columns of u and rows of vt
+			us := make([]float64, m*k)
+			for r := 0; r < m; r++ {
+				for c := 0; c < k; c++ {
+					us[r*k+c] = um[r*uc+c] * sm[c]
+				}
+			}
+			if !same(mul(us, vm[:k*n], m, k, n), ad[b*m*n:(b+1)*m*n]) {
+				t.Log("Test", i, "u·diag(s)·vt != a", u, s, vt)
+				t.Fail()
+			}
+			if !same(mul(mat.Transpose(um, m, uc), um, uc, m, uc), eye(uc)) {
+				t.Log("Test", i, "u is not orthonormal", u)
+				t.Fail()
+			}
+			if !same(mul(vm, mat.Transpose(vm, vr, n), vr, n, vr), eye(vr)) {
+				t.Log("Test", i, "vt is not orthonormal", vt)
+				t.Fail()
+			}
+			for c := 1; c < k; c++ {
+				if sm[c] > sm[c-1] || sm[c] < 0 {
+					t.Log("Test", i, "Singular values not in decreasing order", s)
+					t.Fail()
+				}
+			}
+		}
+	}
+
+	if u, s, vt := SVD(numgo.NewArray64(nil, 4), false); u.GetErr() != numgo.ShapeError || !s.HasErr() || !vt.HasErr() {
+		t.Log("Expected ShapeError Got", u, s, vt)
+		t.Fail()
+	}
+}
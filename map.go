@@ -4,13 +4,14 @@ import (
 	"fmt"
 	"runtime"
 	"sort"
+	"sync"
 )
 
-// FoldFunc can be received by Fold and FoldCC to apply as a summary function
+// FoldFunc can be received by Fold and FoldCC on an Array64 to apply as a summary function
 // across one or multiple axes.
 type FoldFunc func([]float64) float64
 
-// MapFunc can be received by Map to modify each element in an array.
+// MapFunc can be received by Map on an Array64 to modify each element in an array.
 type MapFunc func(float64) float64
 
 // cleanAxis removes any duplicate axes and returns the cleaned slice.
@@ -37,7 +38,7 @@ func cleanAxis(axis *[]int) *[]int {
 	return axis
 }
 
-func (a *Array64) valAxis(axis *[]int, mthd string) bool {
+func (a *Array[T]) valAxis(axis *[]int, mthd string) bool {
 	axis = cleanAxis(axis)
 	switch {
 	case a.HasErr():
@@ -68,9 +69,12 @@ func (a *Array64) valAxis(axis *[]int, mthd string) bool {
 
 // collapse will reorganize data by putting element dataset in continuous sections of data slice.
 // Returned Arrayf must be condensed with a summary calculation to create a valid array object.
-func (a *Array64) collapse(axis []int) (int, *Array64) {
+func (a *Array[T]) collapse(axis []int) (int, *Array[T]) {
+	if !a.contig() {
+		return a.C().collapse(axis)
+	}
 	if len(axis) == 0 {
-		r := newArray64(1)
+		r := newArray[T](1)
 		r.data = append(r.data[:0], a.data...)
 		return a.strides[0], r
 	}
@@ -106,7 +110,7 @@ shape:
 		j--
 	}
 
-	tmp := make([]float64, a.strides[0]) // Holds re-arranged data for return
+	tmp := make([]T, a.strides[0]) // Holds re-arranged data for return
 	retChan, compChan := make(chan struct{}), make(chan struct{})
 	defer close(retChan)
 	defer close(compChan)
@@ -171,7 +175,7 @@ shape:
 	<-compChan
 
 	// Create return object.  Data is invalid format until reform is called.
-	b := new(Array64)
+	b := new(Array[T])
 	b.shape = newShape
 	b.strides = make([]int, len(b.shape)+1)
 	b.data = tmp
@@ -190,14 +194,14 @@ shape:
 // In order to leverage this concurrency, MapCC should only be used for complex and CPU-heavy functions.
 //
 // Simple functions should use Fold(f, axes...), as it's more performant on small functions.
-func (a *Array64) FoldCC(f FoldFunc, axis ...int) (ret *Array64) {
+func (a *Array[T]) FoldCC(f func([]T) T, axis ...int) (ret *Array[T]) {
 	if a.valAxis(&axis, "FoldCC") {
 		return a
 	}
 
 	type rt struct {
 		index int
-		value float64
+		value T
 	}
 
 	rfunc := func(c chan rt, i int) {
@@ -208,7 +212,7 @@ func (a *Array64) FoldCC(f FoldFunc, axis ...int) (ret *Array64) {
 			if debug {
 				ret.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
 			}
-			c <- rt{i, 0}
+			c <- rt{index: i}
 		}
 	}
 
@@ -218,7 +222,7 @@ func (a *Array64) FoldCC(f FoldFunc, axis ...int) (ret *Array64) {
 	defer close(retChan)
 	defer close(compChan)
 	go func() {
-		d := make([]float64, ret.strides[0])
+		d := make([]T, ret.strides[0])
 		for i := 0; i+span <= a.strides[0]; i += span {
 			c := <-retChan
 			d[c.index] = c.value
@@ -241,7 +245,7 @@ func (a *Array64) FoldCC(f FoldFunc, axis ...int) (ret *Array64) {
 // Fold applies function f along the given axes.
 // Slice containing all data to be consolidated into an element will be passed to f.
 // Return value will be the resulting element's value.
-func (a *Array64) Fold(f FoldFunc, axis ...int) (ret *Array64) {
+func (a *Array[T]) Fold(f func([]T) T, axis ...int) (ret *Array[T]) {
 	if a.valAxis(&axis, "Fold") {
 		return a
 	}
@@ -266,7 +270,7 @@ func (a *Array64) Fold(f FoldFunc, axis ...int) (ret *Array64) {
 }
 
 // Map applies function f to each element in the array.
-func (a *Array64) Map(f MapFunc) (ret *Array64) {
+func (a *Array[T]) Map(f func(T) T) (ret *Array[T]) {
 	if a == nil || a.err != nil {
 		return a
 	}
@@ -282,9 +286,136 @@ func (a *Array64) Map(f MapFunc) (ret *Array64) {
 		}
 	}()
 
-	ret = newArray64(a.shape...)
+	d := a.flat()
+	ret = newArray[T](a.shape...)
 	for i := 0; i < a.strides[0]; i++ {
-		ret.data[i] = f(a.data[i])
+		ret.data[i] = f(d[i])
 	}
 	return
 }
+
+// mapPanic catches a panic in a Map function and stores it as a FoldMapError on a.
+func (a *Array[T]) mapPanic(r interface{}) {
+	a.err = FoldMapError
+	a.debug = fmt.Sprint(r)
+	if debug {
+		a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+	}
+}
+
+// MapInPlace applies function f to each element in the array.
+// Unlike Map, this modifies the source array.
+func (a *Array[T]) MapInPlace(f func(T) T) (ret *Array[T]) {
+	if a.HasErr() {
+		return a
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			ret = a
+			a.mapPanic(r)
+		}
+	}()
+
+	a.apply(each(f))
+	return a
+}
+
+// MapIndexed applies function f to each element in the array, along with the element's index.
+// The index slice is reused between calls, so f must copy it to keep it.
+// This modifies the source array.
+func (a *Array[T]) MapIndexed(f func(idx []int, v T) T) (ret *Array[T]) {
+	if a.HasErr() {
+		return a
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			ret = a
+			a.mapPanic(r)
+		}
+	}()
+
+	idx := make([]int, len(a.shape))
+	a.apply(func(d []T) {
+		for i := range d {
+			d[i] = f(idx, d[i])
+			for j := len(idx) - 1; j >= 0; j-- {
+				if idx[j]++; idx[j] < a.shape[j] {
+					break
+				}
+				idx[j] = 0
+			}
+		}
+	})
+	return a
+}
+
+// Map2 applies function f to each pair of elements in a and b, storing the result in a.
+// Arrays must be the same size or able to broadcast, the same way as the arithmetic methods.
+// This will modify the source array.
+func (a *Array[T]) Map2(b *Array[T], f func(x, y T) T) (ret *Array[T]) {
+	if a.valBroadcast(b, "Map2") {
+		return a
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			ret = a
+			a.mapPanic(r)
+		}
+	}()
+
+	vec := func(d, v []T) {
+		for i, j := 0, 0; i < len(d); i, j = i+1, j+1 {
+			if j >= len(v) {
+				j = 0
+			}
+			d[i] = f(d[i], v[j])
+		}
+	}
+	sc := func(c T, d []T) {
+		for i := range d {
+			d[i] = f(d[i], c)
+		}
+	}
+	return a.rith(b, vec, sc)
+}
+
+// MapCC applies function f to each element in the array concurrently, modifying the source array.
+// The elements are split into one block per processor, and each block is mapped in its own goroutine.
+// In order to leverage this concurrency, MapCC should only be used for CPU-heavy functions.
+//
+// Simple functions should use MapInPlace(f), as it's more performant on small functions.
+func (a *Array[T]) MapCC(f func(T) T) (ret *Array[T]) {
+	if a.HasErr() {
+		return a
+	}
+
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+	)
+	a.apply(func(d []T) {
+		blk := (len(d) + runtime.GOMAXPROCS(0) - 1) / runtime.GOMAXPROCS(0)
+		for i := 0; i < len(d); i += blk {
+			end := i + blk
+			if end > len(d) {
+				end = len(d)
+			}
+
+			wg.Add(1)
+			go func(d []T) {
+				defer wg.Done()
+				defer func() {
+					if r := recover(); r != nil {
+						once.Do(func() { a.mapPanic(r) })
+					}
+				}()
+				each(f)(d)
+			}(d[i:end])
+		}
+		wg.Wait()
+	})
+	return a
+}
@@ -211,3 +211,109 @@ func TestMap(t *testing.T) {
 		}
 	}
 }
+
+func TestMapInPlace(t *testing.T) {
+	pan := func(d float64) float64 {
+		var f *float64
+		return *f
+	}
+	a := Arange(100).Reshape(2, 1, 5, 2, 5)
+
+	for i, v := range []struct {
+		a, b *Array64
+		f    MapFunc
+		err  error
+	}{
+		{a.C(), a.C().AddC(5), func(d float64) float64 { return d + 5 }, nil},
+		{a.C(), full(2, a.shape...), func(float64) float64 { return 2 }, nil},
+		{a.C().T(), a.C().T().MultC(2), func(d float64) float64 { return d * 2 }, nil},
+		{a.C(), nil, pan, FoldMapError},
+	} {
+		for _, mp := range []func(*Array64, func(float64) float64) *Array64{(*Array64).MapInPlace, (*Array64).MapCC} {
+			in := v.a.C()
+			r := mp(in, v.f)
+			if r != in {
+				t.Log("Test", i, "did not modify the source array")
+				t.Fail()
+			}
+			if e := r.GetErr(); e != v.err {
+				t.Log("Test", i, "Expected error", v.err, "Received", e)
+				t.Fail()
+				continue
+			}
+			if v.err == nil && !r.Equals(v.b).All().At(0) {
+				t.Logf("Test %d failed.  \nExpected:\n %v \nReceived:\n %v\n", i, v.b, r)
+				t.Fail()
+			}
+		}
+	}
+
+	for _, mp := range []func(*Array64, func(float64) float64) *Array64{(*Array64).MapInPlace, (*Array64).MapCC} {
+		if e := mp(a.C().Reshape(100, 100), pan).GetErr(); e != ReshapeError {
+			t.Log("Expected ReshapeError Received", e)
+			t.Fail()
+		}
+	}
+
+	// Views share their data with the source.
+	b := Arange(6).Reshape(2, 3)
+	b.SubArr(1).MapInPlace(func(d float64) float64 { return -d })
+	if !b.Equals(NewArray64([]float64{0, 1, 2, -3, -4, -5}, 2, 3)).All().At(0) {
+		t.Log("View failed", b)
+		t.Fail()
+	}
+}
+
+func TestMapIndexed(t *testing.T) {
+	idx := func(i []int, d float64) float64 { return float64(i[0]*100 + i[1]*10 + i[2]) }
+	if r := Arange(24).Reshape(2, 3, 4).MapIndexed(idx); !r.Equals(NewArray64([]float64{
+		0, 1, 2, 3, 10, 11, 12, 13, 20, 21, 22, 23, 100, 101, 102, 103, 110, 111, 112, 113, 120, 121, 122, 123}, 2, 3, 4)).All().At(0) {
+		t.Log("Index failed", r)
+		t.Fail()
+	}
+	if r := Arange(24).Reshape(2, 3, 4).T().MapIndexed(func(i []int, d float64) float64 { return float64(i[0]) }); r.At(3, 0, 1) != 3 || r.At(1, 2, 0) != 1 {
+		t.Log("Transposed index failed", r)
+		t.Fail()
+	}
+	if e := Arange(4).MapIndexed(func(i []int, d float64) float64 { return float64(i[1]) }).GetErr(); e != FoldMapError {
+		t.Log("Expected FoldMapError Received", e)
+		t.Fail()
+	}
+}
+
+func TestMap2(t *testing.T) {
+	hyp := func(x, y float64) float64 { return x*x + y*y }
+	a := Arange(6).Reshape(2, 3)
+	for i, v := range []struct {
+		a, b, res *Array64
+		err       error
+	}{
+		{a.C(), a.C(), a.C().Mult(a).MultC(2), nil},
+		{a.C(), Arange(3), NewArray64([]float64{0, 2, 8, 9, 17, 29}, 2, 3), nil},
+		{a.C(), NewArray64([]float64{1, 2}, 2, 1), NewArray64([]float64{1, 2, 5, 13, 20, 29}, 2, 3), nil},
+		{Arange(3), NewArray64([]float64{1, 2}, 2, 1), NewArray64([]float64{1, 2, 5, 4, 5, 8}, 2, 3), nil},
+		{a.C(), Arange(4), nil, ShapeError},
+		{a.C(), nil, nil, NilError},
+		{a.C(), &Array64{err: InvIndexError}, nil, InvIndexError},
+	} {
+		r := v.a.Map2(v.b, hyp)
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Received", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !r.Equals(v.res).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Received", r)
+			t.Fail()
+		}
+	}
+
+	if r := NewArrayB([]bool{true, false}, 2).Map2(NewArrayB([]bool{true}, 1), func(x, y bool) bool { return x != y }); r.At(0) || !r.At(1) {
+		t.Log("Bool Map2 failed", r)
+		t.Fail()
+	}
+	if e := a.C().Map2(a, func(x, y float64) float64 { panic("map") }).GetErr(); e != FoldMapError {
+		t.Log("Expected FoldMapError Received", e)
+		t.Fail()
+	}
+}
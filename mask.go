@@ -0,0 +1,182 @@
+package numgo
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Mask returns the elements of the array where the mask is true, in row-major order, as a 1-D array.
+// The mask must have the same shape as the array.  A new array is returned.
+func (a *Array[T]) Mask(m *Arrayb) *Array[T] {
+	if a.valMask(m, "Mask") {
+		return a
+	}
+
+	d := a.flat()
+	r := make([]T, 0, countTrue(m))
+	for i, v := range m.flat() {
+		if v {
+			r = append(r, d[i])
+		}
+	}
+	return NewArray(r)
+}
+
+// SetMask sets the elements of the array where the mask is true to val.
+// The mask must have the same shape as the array.
+//
+// Source Array is returned, for function-chaining design.
+func (a *Array[T]) SetMask(m *Arrayb, val T) *Array[T] {
+	if a.valMask(m, "SetMask") {
+		return a
+	}
+
+	md := m.flat()
+	a.apply(func(d []T) {
+		for i, v := range md {
+			if v {
+				d[i] = val
+			}
+		}
+	})
+	return a
+}
+
+// SetMaskArr sets the elements of the array where the mask is true to the values in vals, in row-major order.
+// vals must hold one value for each true element in the mask, or a single value that is used for all of them.
+//
+// Source Array is returned, for function-chaining design.
+func (a *Array[T]) SetMaskArr(m *Arrayb, vals *Array[T]) *Array[T] {
+	switch {
+	case a.valMask(m, "SetMaskArr"):
+		return a
+	case vals == nil:
+		a.err = NilError
+		if debug {
+			a.debug = "Array received by SetMaskArr() is a Nil pointer."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	case vals.HasErr():
+		a.err = vals.getErr()
+		if debug {
+			a.debug = "Array received by SetMaskArr() is in error."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	}
+
+	n := countTrue(m)
+	if vals.strides[0] != n && vals.strides[0] != 1 {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Array received by SetMaskArr() can not be broadcast.  Mask count: %d  Vals shape: %v", n, vals.shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	}
+
+	vd, md := vals.valCopy(vals.shape), m.flat()
+	a.apply(func(d []T) {
+		j := 0
+		for i, v := range md {
+			if v {
+				d[i] = vd[j%len(vd)]
+				j++
+			}
+		}
+	})
+	return a
+}
+
+// Where creates an array with elements from x where cond is true, and from y where it is false.
+// The three arrays are broadcast together to create the shape of the result.  A new array is returned.
+func Where[T Elem](cond *Arrayb, x, y *Array[T]) (r *Array[T]) {
+	switch {
+	case cond == nil || x == nil || y == nil:
+		r = &Array[T]{err: NilError}
+		if debug {
+			r.debug = "Nil pointer received by Where()"
+			r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return r
+	case cond.HasErr():
+		r = &Array[T]{err: cond.getErr()}
+	case x.HasErr():
+		r = &Array[T]{err: x.getErr()}
+	case y.HasErr():
+		r = &Array[T]{err: y.getErr()}
+	}
+	if r != nil {
+		if debug {
+			r.debug = "Array received by Where() is in error."
+			r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return r
+	}
+
+	sh, ok := broadcastShape(cond.shape, x.shape)
+	if ok {
+		sh, ok = broadcastShape(sh, y.shape)
+	}
+	if !ok {
+		r = &Array[T]{err: ShapeError}
+		if debug {
+			r.debug = fmt.Sprintf("Arrays received by Where() can not be broadcast.  Cond shape: %v  X shape: %v  Y shape: %v", cond.shape, x.shape, y.shape)
+			r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return r
+	}
+
+	r = newArray[T](sh...)
+	c, yd := make([]bool, len(r.data)), make([]T, len(r.data))
+	gather(c, cond.data, cond.offset, sh, broadcastStrides(sh, cond.shape, cond.strides[1:]))
+	gather(r.data, x.data, x.offset, sh, broadcastStrides(sh, x.shape, x.strides[1:]))
+	gather(yd, y.data, y.offset, sh, broadcastStrides(sh, y.shape, y.strides[1:]))
+	for i, v := range c {
+		if !v {
+			r.data[i] = yd[i]
+		}
+	}
+	return r
+}
+
+// valMask checks the array and mask for errors, and that the shapes match.
+func (a *Array[T]) valMask(m *Arrayb, mthd string) bool {
+	switch {
+	case a.HasErr():
+		return true
+	case m == nil || m.data == nil && m.err == nil:
+		a.err = NilError
+		if debug {
+			a.debug = fmt.Sprintf("Mask received by %s() is a Nil pointer.", mthd)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return true
+	case m.err != nil:
+		a.err = m.err
+		if debug {
+			a.debug = fmt.Sprintf("Mask received by %s() is in error.", mthd)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return true
+	case !equalShape(a.shape, m.shape):
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Mask received by %s() does not match the array.  Shape: %v  Mask shape: %v", mthd, a.shape, m.shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return true
+	}
+	return false
+}
+
+// countTrue calculates the number of true elements in a bool array.
+func countTrue(a *Arrayb) (n int) {
+	for _, v := range a.flat() {
+		if v {
+			n++
+		}
+	}
+	return n
+}
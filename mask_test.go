@@ -0,0 +1,137 @@
+package numgo
+
+import "testing"
+
+func init() {
+	debug = true
+}
+
+func TestMask(t *testing.T) {
+	a := Arange(12).Reshape(3, 4)
+	for i, v := range []struct {
+		a   *Array64
+		m   *Arrayb
+		res []float64
+		err error
+	}{
+		{a, a.Greater(FullArray64(7, 3, 4)), []float64{8, 9, 10, 11}, nil},
+		{a, a.Less(FullArray64(0, 3, 4)), []float64{}, nil},
+		{a, Fullb(true, 3, 4), a.data, nil},
+		{cols(a, 2), NewArrayB([]bool{true, false, true}), []float64{2, 10}, nil},
+		{Arange(4).Reshape(2, 2), NewArrayB([]bool{true, false, true, false, false, true}, 2, 3).Slice(All, R(1, 3)), []float64{1, 3}, nil},
+		{Arange(4).Reshape(2, 2), NewArrayB([]bool{true, false, false, false}, 2, 2).Slice(R(None, None, -1)), []float64{2}, nil},
+		{cols(a, 1), NewArrayB([]bool{true, false, false, true, true, false}, 2, 3).T().Slice(All, Idx(0)), []float64{1}, nil},
+		{a, Fullb(true, 4, 3), nil, ShapeError},
+		{a, Fullb(true, 12), nil, ShapeError},
+		{a, nil, nil, NilError},
+		{a, &Arrayb{err: InvIndexError}, nil, InvIndexError},
+		{&Array64{err: IndexError}, Fullb(true, 3, 4), nil, IndexError},
+	} {
+		r := v.a.Mask(v.m)
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !r.Equals(NewArray64(v.res)).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+}
+
+func TestSetMask(t *testing.T) {
+	a := Arange(6).Reshape(2, 3)
+	m := a.Greater(FullArray64(2, 2, 3)).NotEq(NewArrayB([]bool{false, false, false, false, true, false}, 2, 3))
+	if r := a.C().SetMask(m, -1); !r.Equals(NewArray64([]float64{0, 1, 2, -1, 4, -1}, 2, 3)).All().At(0) {
+		t.Log("SetMask Expected [0 1 2 -1 4 -1] Got", r)
+		t.Fail()
+	}
+
+	for i, v := range []struct {
+		vals *Array64
+		m    *Arrayb
+		res  []float64
+		err  error
+	}{
+		{NewArray64([]float64{10, 20}), m, []float64{0, 1, 2, 10, 4, 20}, nil},
+		{FullArray64(7, 1), m, []float64{0, 1, 2, 7, 4, 7}, nil},
+		{NewArray64([]float64{10, 20}, 2, 1), m, []float64{0, 1, 2, 10, 4, 20}, nil},
+		{NewArray64([]float64{1, 2, 3}), m, nil, ShapeError},
+		{NewArray64([]float64{1, 2}), Fullb(true, 6), nil, ShapeError},
+		{nil, m, nil, NilError},
+		{&Array64{err: InvIndexError}, m, nil, InvIndexError},
+	} {
+		r := a.C().SetMaskArr(v.m, v.vals)
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !r.Equals(NewArray64(v.res, 2, 3)).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+
+	// Masks that are views
+	sm := NewArrayB([]bool{true, false, true, false, false, true}, 2, 3).Slice(All, R(1, 3))
+	if r := Arange(4).Reshape(2, 2).SetMask(sm, -1); !r.Equals(NewArray64([]float64{0, -1, 2, -1}, 2, 2)).All().At(0) {
+		t.Log("SetMask with strided mask Expected [0 -1 2 -1] Got", r)
+		t.Fail()
+	}
+	rm := NewArrayB([]bool{true, false, false, false}, 2, 2).Slice(R(None, None, -1))
+	if r := Arange(4).Reshape(2, 2).SetMask(rm, -1); !r.Equals(NewArray64([]float64{0, 1, -1, 3}, 2, 2)).All().At(0) {
+		t.Log("SetMask with reversed mask Expected [0 1 -1 3] Got", r)
+		t.Fail()
+	}
+	if r := Arange(4).Reshape(2, 2).SetMaskArr(sm, NewArray64([]float64{10, 30})); !r.Equals(NewArray64([]float64{0, 10, 2, 30}, 2, 2)).All().At(0) {
+		t.Log("SetMaskArr with strided mask Expected [0 10 2 30] Got", r)
+		t.Fail()
+	}
+	if r := Arange(4).Reshape(2, 2).SetMaskArr(rm, NewArray64([]float64{1, 2})); r.GetErr() != ShapeError {
+		t.Log("SetMaskArr with reversed mask should count one true element", r)
+		t.Fail()
+	}
+
+	// Masked assignment through a view
+	b := Arange(20).Reshape(4, 5)
+	c := cols(b, 3)
+	c.SetMaskArr(NewArrayB([]bool{false, true, true, false}), c.Slice(R(2, 0, -1)))
+	if b.At(1, 3) != 13 || b.At(2, 3) != 8 || b.At(0, 3) != 3 || b.At(3, 3) != 18 {
+		t.Log("SetMaskArr on view failed", b)
+		t.Fail()
+	}
+}
+
+func TestWhere(t *testing.T) {
+	x := Arange(6).Reshape(2, 3)
+	for i, v := range []struct {
+		c    *Arrayb
+		x, y *Array64
+		res  *Array64
+		err  error
+	}{
+		{x.Greater(FullArray64(2, 2, 3)), x, FullArray64(-1, 1), NewArray64([]float64{-1, -1, -1, 3, 4, 5}, 2, 3), nil},
+		{NewArrayB([]bool{true, false, true}), x, x.C().MultC(10), NewArray64([]float64{0, 10, 2, 3, 40, 5}, 2, 3), nil},
+		{NewArrayB([]bool{true, false}, 2, 1), NewArray64([]float64{1, 2, 3}), FullArray64(0, 2, 1), NewArray64([]float64{1, 2, 3, 0, 0, 0}, 2, 3), nil},
+		{Fullb(true, 1), cols(x, 1), FullArray64(0, 1), NewArray64([]float64{1, 4}), nil},
+		{Fullb(true, 4), x, x, nil, ShapeError},
+		{Fullb(true, 3), x, FullArray64(0, 2), nil, ShapeError},
+		{nil, x, x, nil, NilError},
+		{Fullb(true, 3), x, nil, nil, NilError},
+		{&Arrayb{err: IndexError}, x, x, nil, IndexError},
+		{Fullb(true, 3), &Array64{err: InvIndexError}, x, nil, InvIndexError},
+	} {
+		r := Where(v.c, v.x, v.y)
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !r.Equals(v.res).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+}
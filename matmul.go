@@ -1,6 +1,12 @@
 package numgo
 
-import "github.com/Kunde21/numgo/internal"
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"unicode"
+)
 
 //Matrix and vector multiplication implementations
 // - Dot Product
@@ -9,15 +15,23 @@ import "github.com/Kunde21/numgo/internal"
 
 // DotProd calculates the dot (scalar) product of two vectors.
 // NOTE: Only implemented on 1-D arrays, and other sizes are NOOP
-func (a *Array64) DotProd(b *Array64) *Array64 {
+func (a *Array[T]) DotProd(b *Array[T]) *Array[T] {
 	switch {
 	case a.valRith(b, "DotProd"):
 		return a
+	case len(a.shape) == 1 && (len(b.shape) != 1 || a.shape[0] != b.shape[0]):
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Array received by DotProd() can not be matched.  Shape: %v  Val shape: %v", a.shape, b.shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
 	case len(a.shape) == 1:
-		return &Array64{
+		k := kern[T]()
+		return &Array[T]{
 			shape:   []int{1},
 			strides: []int{1, 1},
-			data:    []float64{asm.DotProd(a.data, b.data)},
+			data:    []T{k.dot(a.flat(), b.flat())},
 			err:     nil,
 			debug:   "",
 			stack:   "",
@@ -26,19 +40,679 @@ func (a *Array64) DotProd(b *Array64) *Array64 {
 	return a
 }
 
-func (a *Array64) MatProd(b *Array64) *Array64 {
+// MatProd calculates the matrix product of two arrays.
+//
+// Shapes are handled the same way as numpy's matmul:
+//
+//	1-D x 1-D:  Inner product, returned as a single element array.
+//	2-D x 2-D:  Standard matrix product.
+//	1-D x N-D:  The vector is promoted to a row matrix, the added axis is removed from the result.
+//	N-D x 1-D:  The vector is promoted to a column matrix, the added axis is removed from the result.
+//	N-D x N-D:  Stacks of matrices in the leading axes are multiplied, broadcasting the stack axes.
+//
+// A new array is returned.  Mismatched inner dimensions will generate a ShapeError.
+func (a *Array[T]) MatProd(b *Array[T]) *Array[T] {
+	k := kern[T]()
 	switch {
-	case a.valRith(b, "MatProd"):
+	case a.HasErr(), a.valOp(k.dot != nil, "MatProd"):
+		return a
+	case b == nil:
+		a.err = NilError
+		if debug {
+			a.debug = "Array received by MatProd() is a Nil pointer."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	case b.HasErr():
+		a.err = b.getErr()
+		if debug {
+			a.debug = "Array received by MatProd() is in error."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
 		return a
 	case len(a.shape) == 1 && len(b.shape) == 1:
-		return &Array64{
+		if a.shape[0] != b.shape[0] {
+			goto shape
+		}
+		return &Array[T]{
 			shape:   []int{1},
 			strides: []int{1, 1},
-			data:    []float64{asm.DotProd(a.data, b.data)},
+			data:    []T{k.dot(a.flat(), b.flat())},
 			err:     nil,
 			debug:   "",
 			stack:   "",
 		}
 	}
+
+	{
+		// Promote vectors to matrices, remembering which axes to drop.
+		ash, bsh := a.shape, b.shape
+		if len(ash) == 1 {
+			ash = []int{1, ash[0]}
+		}
+		if len(bsh) == 1 {
+			bsh = []int{bsh[0], 1}
+		}
+
+		n, l, m := ash[len(ash)-2], ash[len(ash)-1], bsh[len(bsh)-1]
+		if l != bsh[len(bsh)-2] {
+			goto shape
+		}
+
+		stk, ok := broadcastShape(ash[:len(ash)-2], bsh[:len(bsh)-2])
+		if !ok {
+			goto shape
+		}
+
+		sh := append(stk, n, m)
+		switch {
+		case len(b.shape) == 1:
+			sh = sh[:len(sh)-1]
+		case len(a.shape) == 1:
+			sh = append(sh[:len(sh)-2], m)
+		}
+		r := newArray[T](sh...)
+
+		// Transpose every matrix in b, so rows of a and columns of b are contiguous for DotProd.
+		ad, bd := a.flat(), b.flat()
+		bt := make([]T, len(bd))
+		for s := 0; s < len(bd); s += l * m {
+			transpose2(bt[s:s+l*m], bd[s:s+l*m], l, m)
+		}
+
+		aOff := stackOffsets(stk, ash[:len(ash)-2], n*l)
+		bOff := stackOffsets(stk, bsh[:len(bsh)-2], l*m)
+		for s := range aOff {
+			as, bs, rs := ad[aOff[s]:aOff[s]+n*l], bt[bOff[s]:bOff[s]+l*m], r.data[s*n*m:(s+1)*n*m]
+			for i := 0; i < n; i++ {
+				row := as[i*l : (i+1)*l]
+				for j := 0; j < m; j++ {
+					rs[i*m+j] = k.dot(row, bs[j*l:(j+1)*l])
+				}
+			}
+		}
+		return r
+	}
+
+shape:
+	a.err = ShapeError
+	if debug {
+		a.debug = fmt.Sprintf("Array received by MatProd() can not be matched.  Shape: %v  Val shape: %v", a.shape, b.shape)
+		a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+	}
 	return a
 }
+
+// stackOffsets calculates the data offset of each matrix in a stack with shape sh,
+// when broadcast up to the stack shape stk.  Each matrix has sz elements.
+func stackOffsets(stk, sh []int, sz int) []int {
+	cnt := 1
+	for _, v := range stk {
+		cnt *= v
+	}
+
+	// Element strides of sh, aligned to the right of stk.  Broadcast axes don't move.
+	st := make([]int, len(stk))
+	for i, j, t := len(stk)-1, len(sh)-1, sz; j >= 0; i, j = i-1, j-1 {
+		if sh[j] != 1 {
+			st[i] = t
+		}
+		t *= sh[j]
+	}
+
+	off, idx := make([]int, cnt), make([]int, len(stk))
+	for s, o := 1, 0; s < cnt; s++ {
+		for i := len(idx) - 1; i >= 0; i-- {
+			idx[i]++
+			o += st[i]
+			if idx[i] < stk[i] {
+				break
+			}
+			o -= st[i] * idx[i]
+			idx[i] = 0
+		}
+		off[s] = o
+	}
+	return off
+}
+
+// Outer calculates the outer product of two arrays.  Both arrays are flattened,
+// and element [i, j] of the 2-D result is a[i]*b[j].
+func (a *Array[T]) Outer(b *Array[T]) *Array[T] {
+	if a.valContract(b, nil, nil, "Outer") {
+		return a
+	}
+
+	k, ad, bd := kern[T](), a.flat(), b.flat()
+	r := newArray[T](len(ad), len(bd))
+	for i, v := range ad {
+		row := r.data[i*len(bd) : (i+1)*len(bd)]
+		copy(row, bd)
+		k.multC(v, row)
+	}
+	return r
+}
+
+// Kron calculates the Kronecker product of two arrays, which is a block array
+// made of b scaled by each element of a.
+//
+// The array with fewer axes is promoted by adding leading axes of length 1, and
+// each axis of the result is the product of the matching axes of a and b.
+func (a *Array[T]) Kron(b *Array[T]) *Array[T] {
+	if a.valContract(b, nil, nil, "Kron") {
+		return a
+	}
+
+	nd := len(a.shape)
+	if len(b.shape) > nd {
+		nd = len(b.shape)
+	}
+	ash, bsh := make([]int, nd), make([]int, nd)
+	for i := range ash {
+		ash[i], bsh[i] = 1, 1
+	}
+	copy(ash[nd-len(a.shape):], a.shape)
+	copy(bsh[nd-len(b.shape):], b.shape)
+
+	// The outer product has the axes of a then b.  Interleave them to form the blocks.
+	perm, sh := make([]int, 0, 2*nd), make([]int, nd)
+	for i := range sh {
+		perm, sh[i] = append(perm, i, nd+i), ash[i]*bsh[i]
+	}
+	return a.Outer(b).Reshape(append(ash, bsh...)...).permute(perm).Reshape(sh...)
+}
+
+// Cross calculates the cross product of the vectors along axis of a and b.
+// Vectors can have 2 or 3 components, and 2 component vectors have a z component of zero.
+//
+// Negative axes count back from the last axis of each array, so axis -1 holds the vectors of
+// arrays with different numbers of axes, such as (N, 3) and (3).
+//
+// The remaining axes are broadcast, and the vectors of the result are placed along axis.
+// When both arrays have 2 component vectors, only the z component is returned and axis is removed.
+func (a *Array[T]) Cross(b *Array[T], axis int) *Array[T] {
+	if a.valContract(b, nil, nil, "Cross") {
+		return a
+	}
+
+	axA, axB := axis, axis
+	if axis < 0 {
+		axA, axB = axis+len(a.shape), axis+len(b.shape)
+	}
+	switch {
+	case axA < 0 || axA >= len(a.shape) || axB < 0 || axB >= len(b.shape):
+		a.err = IndexError
+		if debug {
+			a.debug = fmt.Sprintf("Axis out of range received by Cross().  Shape: %v  Val shape: %v  Axis: %v", a.shape, b.shape, axis)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	case a.shape[axA] != 2 && a.shape[axA] != 3, b.shape[axB] != 2 && b.shape[axB] != 3:
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Vectors received by Cross() need 2 or 3 components.  Shape: %v  Val shape: %v  Axis: %v", a.shape, b.shape, axis)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	}
+
+	freeA, _ := freeAxes(a.shape, []int{axA})
+	freeB, _ := freeAxes(b.shape, []int{axB})
+	ash, bsh := make([]int, len(freeA)), make([]int, len(freeB))
+	for i, v := range freeA {
+		ash[i] = a.shape[v]
+	}
+	for i, v := range freeB {
+		bsh[i] = b.shape[v]
+	}
+	stk, ok := broadcastShape(ash, bsh)
+	if !ok {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Arrays received by Cross() can not be broadcast.  Shape: %v  Val shape: %v  Axis: %v", a.shape, b.shape, axis)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	}
+
+	// Gather each component into its own slice, so the products run on the vector kernels.
+	k, na, nb := kern[T](), a.shape[axA], b.shape[axB]
+	ad, bd := a.permute(append(freeA, axA)).data, b.permute(append(freeB, axB)).data
+	aOff, bOff := stackOffsets(stk, ash, na), stackOffsets(stk, bsh, nb)
+	comp := func(d []T, off []int, n, c int) []T {
+		x := make([]T, len(off))
+		if c < n {
+			for s, o := range off {
+				x[s] = d[o+c]
+			}
+		}
+		return x
+	}
+	ax, ay, az := comp(ad, aOff, na, 0), comp(ad, aOff, na, 1), comp(ad, aOff, na, 2)
+	bx, by, bz := comp(bd, bOff, nb, 0), comp(bd, bOff, nb, 1), comp(bd, bOff, nb, 2)
+
+	// det returns p*q - r*s.
+	det := func(p, q, r, s []T) []T {
+		x, y := append([]T{}, p...), append([]T{}, r...)
+		k.mult(x, q)
+		k.mult(y, s)
+		k.subtr(x, y)
+		return x
+	}
+	cz := det(ax, by, ay, bx)
+	if na == 2 && nb == 2 {
+		if len(stk) == 0 {
+			stk = append(stk, 1)
+		}
+		r := newArray[T](stk...)
+		copy(r.data, cz)
+		return r
+	}
+
+	cx, cy := det(ay, bz, az, by), det(az, bx, ax, bz)
+	r := newArray[T](append(stk, 3)...)
+	for s := range cx {
+		r.data[3*s], r.data[3*s+1], r.data[3*s+2] = cx[s], cy[s], cz[s]
+	}
+	if axis < 0 {
+		axis += len(stk) + 1
+	}
+	if axis >= len(stk) {
+		return r
+	}
+
+	perm := make([]int, 0, len(stk)+1)
+	for i := range stk {
+		if i == axis {
+			perm = append(perm, len(stk))
+		}
+		perm = append(perm, i)
+	}
+	return r.permute(perm)
+}
+
+// Inner calculates the inner product of two arrays, summing the products over the last axis of each.
+// The result has the remaining axes of a, followed by the remaining axes of b.
+func (a *Array[T]) Inner(b *Array[T]) *Array[T] {
+	var axesA, axesB []int
+	if a != nil && b != nil && len(a.shape) > 0 && len(b.shape) > 0 {
+		axesA, axesB = []int{len(a.shape) - 1}, []int{len(b.shape) - 1}
+	}
+	if a.valContract(b, axesA, axesB, "Inner") {
+		return a
+	}
+	return a.Tensordot(b, axesA, axesB)
+}
+
+// Tensordot calculates the tensor product of a and b, summing over the axes in axesA and axesB.
+// Each axis in axesA is contracted against the corresponding axis in axesB, so they must have the same length.
+//
+// The result has the remaining axes of a, followed by the remaining axes of b.
+// When all axes are contracted, a single element array is returned.
+// Empty axis lists will calculate the outer product.
+func (a *Array[T]) Tensordot(b *Array[T], axesA, axesB []int) *Array[T] {
+	if a.valContract(b, axesA, axesB, "Tensordot") {
+		return a
+	}
+
+	freeA, n := freeAxes(a.shape, axesA)
+	freeB, m := freeAxes(b.shape, axesB)
+	l := 1
+	for _, v := range axesA {
+		l *= a.shape[v]
+	}
+
+	// Free axes first in both, so the contracted elements are contiguous rows.
+	at := a.permute(append(append([]int{}, freeA...), axesA...))
+	bt := b.permute(append(append([]int{}, freeB...), axesB...))
+
+	sh := make([]int, 0, len(freeA)+len(freeB))
+	for _, v := range freeA {
+		sh = append(sh, a.shape[v])
+	}
+	for _, v := range freeB {
+		sh = append(sh, b.shape[v])
+	}
+	if len(sh) == 0 {
+		sh = append(sh, 1)
+	}
+
+	k, r := kern[T](), newArray[T](sh...)
+	for i := 0; i < n; i++ {
+		row := at.data[i*l : (i+1)*l]
+		for j := 0; j < m; j++ {
+			r.data[i*m+j] = k.dot(row, bt.data[j*l:(j+1)*l])
+		}
+	}
+	return r
+}
+
+// freeAxes returns the axes of shape that are not in axis, along with their total size.
+func freeAxes(shape, axis []int) (free []int, sz int) {
+	sz = 1
+free:
+	for i, v := range shape {
+		for _, w := range axis {
+			if i == w {
+				continue free
+			}
+		}
+		free = append(free, i)
+		sz *= v
+	}
+	return free, sz
+}
+
+// valContract validates the arguments of a contraction between a and b.
+func (a *Array[T]) valContract(b *Array[T], axesA, axesB []int, mthd string) bool {
+	switch {
+	case a.HasErr(), a.valOp(kern[T]().dot != nil, mthd):
+		return true
+	case b == nil:
+		a.err = NilError
+		if debug {
+			a.debug = "Array received by " + mthd + "() is a Nil pointer."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return true
+	case b.HasErr():
+		a.err = b.getErr()
+		if debug {
+			a.debug = "Array received by " + mthd + "() is in error."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return true
+	case len(axesA) != len(axesB), len(axesA) > len(a.shape), len(axesB) > len(b.shape):
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Axes received by %s() can not be paired.  Shape: %v  Val shape: %v  Axes: %v, %v",
+				mthd, a.shape, b.shape, axesA, axesB)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return true
+	}
+
+	for i := range axesA {
+		if axesA[i] < 0 || axesA[i] >= len(a.shape) || axesB[i] < 0 || axesB[i] >= len(b.shape) {
+			a.err = IndexError
+			if debug {
+				a.debug = fmt.Sprintf("Axis out of range received by %s().  Shape: %v  Val shape: %v  Axes: %v, %v",
+					mthd, a.shape, b.shape, axesA, axesB)
+				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return true
+		}
+		for j := 0; j < i; j++ {
+			if axesA[i] == axesA[j] || axesB[i] == axesB[j] {
+				a.err = InvIndexError
+				if debug {
+					a.debug = fmt.Sprintf("Repeated axis received by %s().  Axes: %v, %v", mthd, axesA, axesB)
+					a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+				}
+				return true
+			}
+		}
+		if a.shape[axesA[i]] != b.shape[axesB[i]] {
+			a.err = ShapeError
+			if debug {
+				a.debug = fmt.Sprintf("Axes received by %s() do not match.  Shape: %v  Val shape: %v  Axes: %v, %v",
+					mthd, a.shape, b.shape, axesA, axesB)
+				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Einsum evaluates the Einstein summation convention on the arrays, as described by spec.
+//
+// Each operand is labeled with one letter per axis, separated by commas, and the output labels follow "->":
+//
+//	Einsum("ij,jk->ik", a, b)   Matrix product
+//	Einsum("bij,bjk->bik", a, b) Stacked matrix product
+//	Einsum("ii->i", a)          Diagonal
+//	Einsum("ij->", a)           Grand sum
+//
+// Labels not in the output are summed over.  Without "->", the output is the labels
+// used exactly once, in alphabetical order.  Repeated labels must have matching axis lengths.
+// Malformed specs will generate an InvIndexError and mismatched lengths a ShapeError.
+func Einsum[T Numeric](spec string, arrays ...*Array[T]) (r *Array[T]) {
+	in, out, ok := parseEinsum(spec)
+	if !ok || len(in) != len(arrays) {
+		r = &Array[T]{err: InvIndexError}
+		if debug {
+			r.debug = fmt.Sprintf("Invalid spec received by Einsum(): %q with %d arrays", spec, len(arrays))
+			r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return r
+	}
+
+	size := make(map[rune]int)
+	for k, v := range arrays {
+		switch {
+		case v == nil:
+			r = &Array[T]{err: NilError}
+			if debug {
+				r.debug = "Einsum() received a Nil pointer array as an argument."
+				r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return r
+		case v.HasErr():
+			r = &Array[T]{err: v.getErr()}
+			if debug {
+				r.debug = "Error in data passed to Einsum()."
+				r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return r
+		case len(in[k]) != len(v.shape):
+			r = &Array[T]{err: InvIndexError}
+			if debug {
+				r.debug = fmt.Sprintf("Labels %q received by Einsum() don't match array shape %v", string(in[k]), v.shape)
+				r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return r
+		}
+
+		for i, l := range in[k] {
+			if s, ok := size[l]; ok && s != v.shape[i] {
+				r = &Array[T]{err: ShapeError}
+				if debug {
+					r.debug = fmt.Sprintf("Label %q received by Einsum() has mismatched lengths %d and %d", l, s, v.shape[i])
+					r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+				}
+				return r
+			}
+			size[l] = v.shape[i]
+		}
+	}
+
+	for _, l := range out {
+		if _, ok := size[l]; !ok {
+			r = &Array[T]{err: InvIndexError}
+			if debug {
+				r.debug = fmt.Sprintf("Output label %q received by Einsum() is not in the inputs: %q", l, spec)
+				r.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return r
+		}
+	}
+
+	if r = einsumDot(in, out, arrays); r != nil {
+		return r
+	}
+
+	// Labels are ordered output first, then summed, so the output index is the outer loop.
+	labels := append([]rune{}, out...)
+	for _, l := range in {
+		labels = appendLabels(labels, l)
+	}
+
+	shape := make([]int, len(labels))
+	for i, l := range labels {
+		shape[i] = size[l]
+	}
+
+	// Strides of each operand over the full label space.  Repeated labels add strides (diagonals).
+	st := make([][]int, len(arrays))
+	for k, v := range arrays {
+		st[k] = make([]int, len(labels))
+		for i, l := range in[k] {
+			for j, m := range labels {
+				if l == m {
+					st[k][j] += v.strides[i+1]
+				}
+			}
+		}
+	}
+
+	sh := make([]int, len(out))
+	copy(sh, shape)
+	if len(sh) == 0 {
+		sh = append(sh, 1)
+	}
+	r = newArray[T](sh...)
+
+	inner := 1
+	for _, v := range shape[len(out):] {
+		inner *= v
+	}
+
+	total := r.strides[0] * inner
+	idx, off := make([]int, len(labels)), make([]int, len(arrays))
+	for k, v := range arrays {
+		off[k] = v.offset
+	}
+	for n := 0; n < total; n++ {
+		p := T(1)
+		for k, v := range arrays {
+			p *= v.data[off[k]]
+		}
+		r.data[n/inner] += p
+
+		for i := len(idx) - 1; i >= 0; i-- {
+			idx[i]++
+			for k := range off {
+				off[k] += st[k][i]
+			}
+			if idx[i] < shape[i] {
+				break
+			}
+			for k := range off {
+				off[k] -= st[k][i] * idx[i]
+			}
+			idx[i] = 0
+		}
+	}
+	return r
+}
+
+// parseEinsum splits an Einsum spec into the operand and output labels.
+func parseEinsum(spec string) (in [][]rune, out []rune, ok bool) {
+	spec = strings.Replace(spec, " ", "", -1)
+	ops, res := spec, ""
+	explicit := strings.Contains(spec, "->")
+	if explicit {
+		parts := strings.Split(spec, "->")
+		if len(parts) != 2 {
+			return nil, nil, false
+		}
+		ops, res = parts[0], parts[1]
+	}
+
+	cnt := make(map[rune]int)
+	for _, op := range strings.Split(ops, ",") {
+		l := []rune(op)
+		for _, c := range l {
+			if !unicode.IsLetter(c) {
+				return nil, nil, false
+			}
+			cnt[c]++
+		}
+		in = append(in, l)
+	}
+
+	if !explicit {
+		for l, c := range cnt {
+			if c == 1 {
+				out = append(out, l)
+			}
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+		return in, out, true
+	}
+
+	out = []rune(res)
+	for i, l := range out {
+		if !unicode.IsLetter(l) || labelIndex(out[:i], l) >= 0 {
+			return nil, nil, false
+		}
+	}
+	return in, out, true
+}
+
+// appendLabels adds the labels in l that aren't already in labels.
+func appendLabels(labels, l []rune) []rune {
+	for _, v := range l {
+		if labelIndex(labels, v) < 0 {
+			labels = append(labels, v)
+		}
+	}
+	return labels
+}
+
+// labelIndex returns the position of label l in labels, or -1 if it's not present.
+func labelIndex(labels []rune, l rune) int {
+	for i, v := range labels {
+		if v == l {
+			return i
+		}
+	}
+	return -1
+}
+
+// einsumDot evaluates two operand specs that reduce to a single Tensordot call.
+// A nil return means the spec needs the general evaluation.
+func einsumDot[T Elem](in [][]rune, out []rune, arrays []*Array[T]) *Array[T] {
+	if len(in) != 2 {
+		return nil
+	}
+
+	var axesA, axesB []int
+	for i, l := range in[0] {
+		j, inOut := labelIndex(in[1], l), labelIndex(out, l) >= 0
+		switch {
+		case labelIndex(in[0][:i], l) >= 0:
+			return nil
+		case j >= 0 && !inOut:
+			axesA, axesB = append(axesA, i), append(axesB, j)
+		case j >= 0 || !inOut:
+			return nil
+		}
+	}
+	for i, l := range in[1] {
+		if labelIndex(in[1][:i], l) >= 0 || labelIndex(in[0], l) < 0 && labelIndex(out, l) < 0 {
+			return nil
+		}
+	}
+
+	r := arrays[0].Tensordot(arrays[1], axesA, axesB)
+
+	// Result labels are the free labels of a then b.  Reorder them to the output.
+	var res []rune
+	for _, l := range append(append([]rune{}, in[0]...), in[1]...) {
+		if labelIndex(out, l) >= 0 {
+			res = append(res, l)
+		}
+	}
+	perm := make([]int, len(out))
+	for i, l := range out {
+		perm[i] = labelIndex(res, l)
+	}
+	for i, v := range perm {
+		if i != v {
+			return r.permute(perm)
+		}
+	}
+	return r
+}
@@ -38,6 +38,311 @@ func TestDotProd(t *testing.T) {
 	}
 }
 
+// matProd is a naive reference implementation for 2-D matrix products.
+func matProd(a, b *Array64) *Array64 {
+	n, k, m := a.shape[0], a.shape[1], b.shape[1]
+	r := NewArray64(nil, n, m)
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			for p := 0; p < k; p++ {
+				r.data[i*m+j] += a.data[i*k+p] * b.data[p*m+j]
+			}
+		}
+	}
+	return r
+}
+
+func TestMatProd(t *testing.T) {
+	a, b := Arange(6).Reshape(2, 3), Arange(12).Reshape(3, 4)
+	stk := Arange(24).Reshape(2, 3, 4)
+	for i, v := range []struct {
+		a, b  *Array64
+		shape []int
+		res   *Array64
+		e     error
+	}{
+		{Arange(5), Arange(5), []int{1}, NewArray64([]float64{30}), nil},
+		{a, b, []int{2, 4}, NewArray64([]float64{20, 23, 26, 29, 56, 68, 80, 92}, 2, 4), nil},
+		{a, Arange(3), []int{2}, NewArray64([]float64{5, 14}), nil},
+		{Arange(2), a, []int{3}, NewArray64([]float64{3, 4, 5}), nil},
+		{Arange(3), stk, []int{2, 4}, NewArray64([]float64{20, 23, 26, 29, 56, 59, 62, 65}, 2, 4), nil},
+		{a.C().Reshape(1, 2, 3), stk, []int{2, 2, 4}, nil, nil},
+		{stk.C().Reshape(2, 1, 3, 4), Arange(12).Reshape(3, 4, 1), []int{2, 3, 3, 1}, nil, nil},
+		{a, a, nil, nil, ShapeError},
+		{Arange(4), Arange(5), nil, nil, ShapeError},
+		{Arange(6).Reshape(3, 1, 2), Arange(12).Reshape(2, 2, 3), nil, nil, ShapeError},
+		{a, nil, nil, nil, NilError},
+		{a, &Array64{err: InvIndexError}, nil, nil, InvIndexError},
+	} {
+		c := v.a.C().MatProd(v.b)
+		if e := c.GetErr(); e != v.e {
+			t.Log("Error test", i, "Expected", v.e, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.e != nil {
+			continue
+		}
+		if len(c.shape) != len(v.shape) {
+			t.Log("Shape test", i, "Expected", v.shape, "Got", c.shape)
+			t.Fail()
+			continue
+		}
+		for j := range v.shape {
+			if c.shape[j] != v.shape[j] {
+				t.Log("Shape test", i, "Expected", v.shape, "Got", c.shape)
+				t.Fail()
+			}
+		}
+		if v.res != nil && !c.Equals(v.res).All().At(0) {
+			t.Log("Value test", i, "Expected", v.res, "Got", c)
+			t.Fail()
+		}
+	}
+
+	// Stacked products must match the product of each matrix.
+	c := a.MatProd(stk)
+	for i := 0; i < 2; i++ {
+		if !c.SubArr(i).Equals(matProd(a, stk.SubArr(i))).All().At(0) {
+			t.Log("Stack test", i, "Expected", matProd(a, stk.SubArr(i)), "Got", c.SubArr(i))
+			t.Fail()
+		}
+	}
+
+	x, y := RandArray64(-1, 2, 17, 33), RandArray64(-1, 2, 33, 9)
+	if c, e := x.MatProd(y), matProd(x, y); !c.Subtr(e).Map(math.Abs).Less(full(1e-12, 17, 9)).All().At(0) {
+		t.Log("Random test expected", e, "Got", c)
+		t.Fail()
+	}
+}
+
+func TestTensordot(t *testing.T) {
+	a, b := Arange(24).Reshape(2, 3, 4), Arange(12).Reshape(4, 3)
+	for i, v := range []struct {
+		a, b         *Array64
+		axesA, axesB []int
+		res          *Array64
+		e            error
+	}{
+		{Arange(6).Reshape(2, 3), Arange(12).Reshape(3, 4), []int{1}, []int{0},
+			NewArray64([]float64{20, 23, 26, 29, 56, 68, 80, 92}, 2, 4), nil},
+		{Arange(6).Reshape(3, 2), Arange(12).Reshape(3, 4), []int{0}, []int{0},
+			NewArray64([]float64{40, 46, 52, 58, 52, 61, 70, 79}, 2, 4), nil},
+		{Arange(4), Arange(3), []int{}, []int{}, NewArray64([]float64{0, 0, 0, 0, 1, 2, 0, 2, 4, 0, 3, 6}, 4, 3), nil},
+		{Arange(6).Reshape(2, 3), Arange(6).Reshape(2, 3), []int{0, 1}, []int{0, 1}, NewArray64([]float64{55}), nil},
+		{a, b, []int{1, 2}, []int{1, 0}, NewArray64([]float64{440, 1232}), nil},
+		{a, b, []int{1}, []int{0}, nil, ShapeError},
+		{a, b, []int{1, 2}, []int{1}, nil, ShapeError},
+		{a, b, []int{3}, []int{0}, nil, IndexError},
+		{a, b, []int{2, 2}, []int{0, 0}, nil, InvIndexError},
+		{a, nil, []int{2}, []int{0}, nil, NilError},
+		{a, &Array64{err: InvIndexError}, []int{2}, []int{0}, nil, InvIndexError},
+	} {
+		c := v.a.C().Tensordot(v.b, v.axesA, v.axesB)
+		if e := c.GetErr(); e != v.e {
+			t.Log("Error test", i, "Expected", v.e, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.e == nil && !c.Equals(v.res).All().At(0) {
+			t.Log("Value test", i, "Expected", v.res, "Got", c)
+			t.Fail()
+		}
+	}
+
+	x, y := RandArray64(-1, 2, 5, 7, 3), RandArray64(-1, 2, 3, 7, 2)
+	c := x.Tensordot(y, []int{1, 2}, []int{1, 0})
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 2; j++ {
+			var e float64
+			for p := 0; p < 7; p++ {
+				for q := 0; q < 3; q++ {
+					e += x.At(i, p, q) * y.At(q, p, j)
+				}
+			}
+			if math.Abs(c.At(i, j)-e) > 1e-12 {
+				t.Log("Random test", i, j, "Expected", e, "Got", c.At(i, j))
+				t.Fail()
+			}
+		}
+	}
+}
+
+func TestOuter(t *testing.T) {
+	a := Arange(6).Reshape(2, 3)
+	for i, v := range []struct {
+		c, res *Array64
+		e      error
+	}{
+		{Arange(3).Outer(NewArray64([]float64{1, 2})), NewArray64([]float64{0, 0, 1, 2, 2, 4}, 3, 2), nil},
+		{Arange(4).Reshape(2, 2).Outer(NewArray64([]float64{1, -1})), NewArray64([]float64{0, 0, 1, -1, 2, -2, 3, -3}, 4, 2), nil},
+		{a.C().T().Outer(NewArray64([]float64{2})), NewArray64([]float64{0, 6, 2, 8, 4, 10}, 6, 1), nil},
+		{a.C().Outer(nil), nil, NilError},
+		{a.C().Outer(&Array64{err: InvIndexError}), nil, InvIndexError},
+	} {
+		if e := v.c.GetErr(); e != v.e {
+			t.Log("Error test", i, "Expected", v.e, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.e == nil && !v.c.Equals(v.res).All().At(0) {
+			t.Log("Value test", i, "Expected", v.res, "Got", v.c)
+			t.Fail()
+		}
+	}
+
+	if e := NewArrayB([]bool{true}, 1).Outer(NewArrayB([]bool{true}, 1)).GetErr(); e != TypeError {
+		t.Log("Expected TypeError Got", e)
+		t.Fail()
+	}
+}
+
+func TestKron(t *testing.T) {
+	id := Identity(2)
+	for i, v := range []struct {
+		c, res *Array64
+		e      error
+	}{
+		{NewArray64([]float64{1, 10, 100}).Kron(NewArray64([]float64{5, 6, 7})),
+			NewArray64([]float64{5, 6, 7, 50, 60, 70, 500, 600, 700}), nil},
+		{id.C().Kron(NewArray64([]float64{1, 2, 3, 4}, 2, 2)),
+			NewArray64([]float64{1, 2, 0, 0, 3, 4, 0, 0, 0, 0, 1, 2, 0, 0, 3, 4}, 4, 4), nil},
+		{NewArray64([]float64{1, 2, 3, 4}, 2, 2).Kron(id),
+			NewArray64([]float64{1, 0, 2, 0, 0, 1, 0, 2, 3, 0, 4, 0, 0, 3, 0, 4}, 4, 4), nil},
+		{NewArray64([]float64{1, 2}).Kron(id), NewArray64([]float64{1, 0, 2, 0, 0, 1, 0, 2}, 2, 4), nil},
+		{Arange(2).AddC(1).Reshape(2, 1, 1).Kron(Arange(6).Reshape(2, 3)),
+			NewArray64([]float64{0, 1, 2, 3, 4, 5, 0, 2, 4, 6, 8, 10}, 2, 2, 3), nil},
+		{id.C().Kron(nil), nil, NilError},
+	} {
+		if e := v.c.GetErr(); e != v.e {
+			t.Log("Error test", i, "Expected", v.e, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.e == nil && !v.c.Equals(v.res).All().At(0) {
+			t.Log("Value test", i, "Expected", v.res, "Got", v.c)
+			t.Fail()
+		}
+	}
+}
+
+func TestCross(t *testing.T) {
+	x, y := NewArray64([]float64{1, 0, 0}), NewArray64([]float64{0, 1, 0})
+	for i, v := range []struct {
+		c, res *Array64
+		e      error
+	}{
+		{x.Cross(y, 0), NewArray64([]float64{0, 0, 1}), nil},
+		{y.Cross(x, 0), NewArray64([]float64{0, 0, -1}), nil},
+		{NewArray64([]float64{1, 2, 3}).Cross(NewArray64([]float64{4, 5, 6}), 0), NewArray64([]float64{-3, 6, -3}), nil},
+		{NewArray64([]float64{1, 2}).Cross(NewArray64([]float64{4, 5}), 0), NewArray64([]float64{-3}), nil},
+		{NewArray64([]float64{1, 2}).Cross(NewArray64([]float64{4, 5, 6}), 0), NewArray64([]float64{12, -6, -3}), nil},
+		{NewArray64([]float64{1, 2, 3, 0, 0, 1}, 2, 3).Cross(NewArray64([]float64{4, 5, 6}, 1, 3), 1),
+			NewArray64([]float64{-3, 6, -3, -5, 4, 0}, 2, 3), nil},
+		{NewArray64([]float64{1, 0, 2, 0, 3, 1}, 3, 2).Cross(NewArray64([]float64{4, 5, 6}).Reshape(3, 1), 0),
+			NewArray64([]float64{-3, -5, 6, 4, -3, 0}, 3, 2), nil},
+		{NewArray64([]float64{1, 2, 3, 4}, 2, 2).Cross(NewArray64([]float64{0, 1, 1, 0}, 2, 2), 1), NewArray64([]float64{1, -4}), nil},
+		{NewArray64([]float64{1, 2, 3, 0, 0, 1}, 2, 3).Cross(y, -1), NewArray64([]float64{-3, 0, 1, -1, 0, 0}, 2, 3), nil},
+		{y.Cross(NewArray64([]float64{1, 2, 3, 0, 0, 1}, 2, 3), -1), NewArray64([]float64{3, 0, -1, 1, 0, 0}, 2, 3), nil},
+		{NewArray64([]float64{1, 0, 2, 0, 3, 1}, 3, 2).Cross(NewArray64([]float64{0, 1, 0}).Reshape(3, 1), -2), NewArray64([]float64{-3, -1, 0, 0, 1, 0}, 3, 2), nil},
+		{NewArray64([]float64{1, 2, 3, 4}, 2, 2).Cross(NewArray64([]float64{0, 1}), -1), NewArray64([]float64{1, 3}), nil},
+		{Arange(24).Reshape(2, 4, 3).Cross(Arange(12).Reshape(4, 3), -1),
+			Arange(24).Reshape(2, 4, 3).Cross(Arange(12).Reshape(1, 4, 3), 2), nil},
+		{x.C().Cross(y, 1), nil, IndexError},
+		{x.C().Cross(Arange(6).Reshape(2, 3), -2), nil, IndexError},
+		{Arange(4).Cross(Arange(4), 0), nil, ShapeError},
+		{Arange(6).Reshape(2, 3).Cross(Arange(9).Reshape(3, 3), 1), nil, ShapeError},
+		{x.C().Cross(nil, 0), nil, NilError},
+	} {
+		if e := v.c.GetErr(); e != v.e {
+			t.Log("Error test", i, "Expected", v.e, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.e == nil && !v.c.Equals(v.res).All().At(0) {
+			t.Log("Value test", i, "Expected", v.res, "Got", v.c)
+			t.Fail()
+		}
+	}
+}
+
+func TestInner(t *testing.T) {
+	a, b := Arange(6).Reshape(2, 3), Arange(12).Reshape(4, 3)
+	for i, v := range []struct {
+		c, res *Array64
+		e      error
+	}{
+		{Arange(3).Inner(Arange(3)), NewArray64([]float64{5}), nil},
+		{a.C().Inner(b), a.MatProd(b.C().T()), nil},
+		{a.C().Inner(Arange(3)), NewArray64([]float64{5, 14}), nil},
+		{Arange(24).Reshape(2, 3, 4).Inner(Arange(8).Reshape(2, 4)).Reshape(6, 2),
+			Arange(24).Reshape(6, 4).MatProd(Arange(8).Reshape(2, 4).T()), nil},
+		{a.C().Inner(Arange(4)), nil, ShapeError},
+		{a.C().Inner(nil), nil, NilError},
+	} {
+		if e := v.c.GetErr(); e != v.e {
+			t.Log("Error test", i, "Expected", v.e, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.e == nil && !v.c.Equals(v.res).All().At(0) {
+			t.Log("Value test", i, "Expected", v.res, "Got", v.c)
+			t.Fail()
+		}
+	}
+}
+
+func TestEinsum(t *testing.T) {
+	a, b := Arange(6).Reshape(2, 3), Arange(12).Reshape(3, 4)
+	stk := Arange(24).Reshape(2, 3, 4)
+	for i, v := range []struct {
+		spec   string
+		arrays []*Array64
+		res    *Array64
+		e      error
+	}{
+		{"ij,jk->ik", []*Array64{a, b}, a.MatProd(b), nil},
+		{"ij,jk", []*Array64{a, b}, a.MatProd(b), nil},
+		{"ij,jk->ki", []*Array64{a, b}, NewArray64([]float64{20, 56, 23, 68, 26, 80, 29, 92}, 4, 2), nil},
+		{"bij,bjk->bik", []*Array64{a.C().Reshape(1, 2, 3).Append(a.C().Reshape(1, 2, 3), 0), stk}, nil, nil},
+		{"i,i->", []*Array64{Arange(5), Arange(5)}, NewArray64([]float64{30}), nil},
+		{"i,j->ij", []*Array64{Arange(2), Arange(3)}, NewArray64([]float64{0, 0, 0, 0, 1, 2}, 2, 3), nil},
+		{"ij->ji", []*Array64{a}, NewArray64([]float64{0, 3, 1, 4, 2, 5}, 3, 2), nil},
+		{"ij->", []*Array64{a}, NewArray64([]float64{15}), nil},
+		{"ij->j", []*Array64{a}, NewArray64([]float64{3, 5, 7}), nil},
+		{"ii->i", []*Array64{Arange(9).Reshape(3, 3)}, NewArray64([]float64{0, 4, 8}), nil},
+		{"ii", []*Array64{Arange(9).Reshape(3, 3)}, NewArray64([]float64{12}), nil},
+		{"ij,jk,kl->il", []*Array64{a, b, Identity(4)}, a.MatProd(b), nil},
+		{"ij,jk->ik", []*Array64{a}, nil, InvIndexError},
+		{"i1,jk->ik", []*Array64{a, b}, nil, InvIndexError},
+		{"ij,jk->iz", []*Array64{a, b}, nil, InvIndexError},
+		{"ij,jk->ii", []*Array64{a, b}, nil, InvIndexError},
+		{"ijk,jk->ik", []*Array64{a, b}, nil, InvIndexError},
+		{"ij,ik->jk", []*Array64{a, b}, nil, ShapeError},
+		{"ij,jk->ik", []*Array64{a, nil}, nil, NilError},
+		{"ij,jk->ik", []*Array64{a, {err: InvIndexError}}, nil, InvIndexError},
+	} {
+		c := Einsum(v.spec, v.arrays...)
+		if e := c.GetErr(); e != v.e {
+			t.Log("Error test", i, v.spec, "Expected", v.e, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.res != nil && !c.Equals(v.res).All().At(0) {
+			t.Log("Value test", i, v.spec, "Expected", v.res, "Got", c)
+			t.Fail()
+		}
+	}
+
+	x := Einsum("bij,bjk->bik", stk.C().Reshape(2, 4, 3), stk)
+	for i := 0; i < 2; i++ {
+		if !x.SubArr(i).Equals(matProd(stk.C().Reshape(2, 4, 3).SubArr(i), stk.SubArr(i))).All().At(0) {
+			t.Log("Batch test", i, "Got", x.SubArr(i))
+			t.Fail()
+		}
+	}
+}
+
 func BenchmarkDotProd(t *testing.B) {
 	a, b := Arange(1000000), Arange(1000000)
 
@@ -47,3 +352,13 @@ func BenchmarkDotProd(t *testing.B) {
 		_ = a.DotProd(b)
 	}
 }
+
+func BenchmarkMatProd(t *testing.B) {
+	a, b := RandArray64(0, 1, 256, 256), RandArray64(0, 1, 256, 256)
+
+	t.ReportAllocs()
+	t.ResetTimer()
+	for i := 0; i < t.N; i++ {
+		_ = a.MatProd(b)
+	}
+}
@@ -0,0 +1,356 @@
+package numgo
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Numpy .npy and .npz files.
+//
+// A .npy file holds one array: a magic string and version, a header describing the element type,
+// order and shape of the array, then the raw element data.  A .npz file is a zip archive of .npy files.
+// See https://numpy.org/doc/stable/reference/generated/numpy.lib.format.html
+
+const npyMagic = "\x93NUMPY"
+
+// npyMaxHeader is the largest header ReadNpy accepts, matching the limit numpy reads by default.
+// Headers of the supported element types are far smaller, so larger lengths are treated as corrupt.
+const npyMaxHeader = 10000
+
+// npyKinds maps element type names to their numpy type codes, without the byte order.
+var npyKinds = map[string]string{
+	"bool": "b1",
+	"int8": "i1", "int16": "i2", "int32": "i4", "int64": "i8",
+	"uint8": "u1", "uint16": "u2", "uint32": "u4", "uint64": "u8",
+	"float32": "f4", "float64": "f8",
+	"complex64": "c8", "complex128": "c16",
+}
+
+var (
+	npyDescr   = regexp.MustCompile(`['"]descr['"]\s*:\s*['"]([<>|=])([biufc])(\d+)['"]`)
+	npyFortran = regexp.MustCompile(`['"]fortran_order['"]\s*:\s*(True|False)`)
+	npyShape   = regexp.MustCompile(`['"]shape['"]\s*:\s*\(([^)]*)\)`)
+)
+
+// npyType describes the stored element type of a .npy file.
+type npyType struct {
+	order binary.ByteOrder
+	kind  byte
+	size  int
+}
+
+// parseNpyType parses the element type from the descr value of a header.
+func parseNpyType(order, kind, size string) (t npyType, ok bool) {
+	t.order, t.kind = binary.ByteOrder(binary.LittleEndian), kind[0]
+	if order == ">" {
+		t.order = binary.BigEndian
+	}
+	t.size, _ = strconv.Atoi(size)
+
+	for _, v := range npyKinds {
+		if v == kind+size {
+			return t, true
+		}
+	}
+	return t, false
+}
+
+// get decodes an element from b.
+func (t npyType) get(b []byte) scalar {
+	switch t.kind {
+	case 'b':
+		if b[0] != 0 {
+			return scalar{kind: 'i', i: 1}
+		}
+		return scalar{kind: 'i'}
+	case 'i':
+		switch t.size {
+		case 1:
+			return scalar{kind: 'i', i: int64(int8(b[0]))}
+		case 2:
+			return scalar{kind: 'i', i: int64(int16(t.order.Uint16(b)))}
+		case 4:
+			return scalar{kind: 'i', i: int64(int32(t.order.Uint32(b)))}
+		}
+		return scalar{kind: 'i', i: int64(t.order.Uint64(b))}
+	case 'u':
+		switch t.size {
+		case 1:
+			return scalar{kind: 'u', u: uint64(b[0])}
+		case 2:
+			return scalar{kind: 'u', u: uint64(t.order.Uint16(b))}
+		case 4:
+			return scalar{kind: 'u', u: uint64(t.order.Uint32(b))}
+		}
+		return scalar{kind: 'u', u: t.order.Uint64(b)}
+	case 'f':
+		if t.size == 4 {
+			return scalar{kind: 'f', f: float64(math.Float32frombits(t.order.Uint32(b)))}
+		}
+		return scalar{kind: 'f', f: math.Float64frombits(t.order.Uint64(b))}
+	}
+	if t.size == 8 {
+		re, im := math.Float32frombits(t.order.Uint32(b)), math.Float32frombits(t.order.Uint32(b[4:]))
+		return scalar{kind: 'c', c: complex(float64(re), float64(im))}
+	}
+	return scalar{kind: 'c', c: complex(math.Float64frombits(t.order.Uint64(b)), math.Float64frombits(t.order.Uint64(b[8:])))}
+}
+
+// put encodes an element into b.  s must hold a value of the stored element type.
+func (t npyType) put(b []byte, s scalar) {
+	switch t.kind {
+	case 'b':
+		b[0] = byte(s.i)
+	case 'i', 'u':
+		v := s.u
+		if s.kind == 'i' {
+			v = uint64(s.i)
+		}
+		switch t.size {
+		case 1:
+			b[0] = byte(v)
+		case 2:
+			t.order.PutUint16(b, uint16(v))
+		case 4:
+			t.order.PutUint32(b, uint32(v))
+		default:
+			t.order.PutUint64(b, v)
+		}
+	case 'f':
+		if t.size == 4 {
+			t.order.PutUint32(b, math.Float32bits(float32(s.f)))
+			return
+		}
+		t.order.PutUint64(b, math.Float64bits(s.f))
+	case 'c':
+		if t.size == 8 {
+			t.order.PutUint32(b, math.Float32bits(float32(real(s.c))))
+			t.order.PutUint32(b[4:], math.Float32bits(float32(imag(s.c))))
+			return
+		}
+		t.order.PutUint64(b, math.Float64bits(real(s.c)))
+		t.order.PutUint64(b[8:], math.Float64bits(imag(s.c)))
+	}
+}
+
+// npyErr creates an array holding a FormatError, for data received by mthd that can't be decoded.
+func npyErr[T Elem](mthd, format string, args ...interface{}) *Array[T] {
+	a := &Array[T]{err: FormatError}
+	if debug {
+		a.debug = fmt.Sprintf("Data received by %s() can not be decoded.  ", mthd) + fmt.Sprintf(format, args...)
+		a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+	}
+	return a
+}
+
+// ReadNpy reads an array in the numpy .npy format from r.
+//
+// Boolean, integer, floating point and complex element types are supported, in either byte order,
+// and arrays stored in Fortran order are rearranged into row-major order.  Stored values are
+// converted to T the same way as AsType without checking, so ReadNpy[float64] gives an Array64
+// and ReadNpy[bool] gives an Arrayb from any of the stored types.  A 0-D array has shape [1].
+//
+// Malformed or truncated data, and unsupported element types, generate a FormatError.
+func ReadNpy[T Elem](r io.Reader) *Array[T] {
+	pre := make([]byte, 10)
+	if _, err := io.ReadFull(r, pre[:8]); err != nil {
+		return npyErr[T]("ReadNpy", "Error: %v", err)
+	}
+	if string(pre[:6]) != npyMagic {
+		return npyErr[T]("ReadNpy", "Not a .npy file.  Magic: %q", pre[:6])
+	}
+
+	var hl int
+	switch pre[6] {
+	case 1:
+		if _, err := io.ReadFull(r, pre[8:10]); err != nil {
+			return npyErr[T]("ReadNpy", "Error: %v", err)
+		}
+		hl = int(binary.LittleEndian.Uint16(pre[8:10]))
+	case 2, 3:
+		if _, err := io.ReadFull(r, pre[:4]); err != nil {
+			return npyErr[T]("ReadNpy", "Error: %v", err)
+		}
+		hl = int(binary.LittleEndian.Uint32(pre[:4]))
+	default:
+		return npyErr[T]("ReadNpy", "Unsupported version: %d.%d", pre[6], pre[7])
+	}
+
+	if hl > npyMaxHeader {
+		return npyErr[T]("ReadNpy", "Header length %d exceeds the limit of %d bytes", hl, npyMaxHeader)
+	}
+	hdr := make([]byte, hl)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return npyErr[T]("ReadNpy", "Error: %v", err)
+	}
+	typ, fortran, shape, ok := parseNpyHeader(string(hdr))
+	if !ok {
+		return npyErr[T]("ReadNpy", "Unsupported header: %s", strings.TrimSpace(string(hdr)))
+	}
+
+	// The data is read without trusting the header to size the buffer.
+	ln := size(shape) * typ.size
+	buf, err := io.ReadAll(io.LimitReader(r, int64(ln)))
+	if err != nil || len(buf) < ln {
+		return npyErr[T]("ReadNpy", "Data for shape %v.  Received %d of %d bytes.  Error: %v", shape, len(buf), ln, err)
+	}
+
+	k := kern[T]()
+	sh := shape
+	if fortran {
+		sh = make([]int, len(shape))
+		for i, v := range shape {
+			sh[len(shape)-i-1] = v
+		}
+	}
+	a := newArray[T](sh...)
+	for i := range a.data {
+		a.data[i], _ = k.store(typ.get(buf[i*typ.size:]), RoundTrunc)
+	}
+	if !fortran || len(sh) < 2 {
+		return a
+	}
+
+	perm := make([]int, len(sh))
+	for i := range perm {
+		perm[i] = len(sh) - i - 1
+	}
+	return a.permute(perm)
+}
+
+// parseNpyHeader parses the element type, order and shape from the header of a .npy file.
+func parseNpyHeader(hdr string) (typ npyType, fortran bool, shape []int, ok bool) {
+	d, f, s := npyDescr.FindStringSubmatch(hdr), npyFortran.FindStringSubmatch(hdr), npyShape.FindStringSubmatch(hdr)
+	if d == nil || f == nil || s == nil {
+		return typ, false, nil, false
+	}
+	if typ, ok = parseNpyType(d[1], d[2], d[3]); !ok {
+		return typ, false, nil, false
+	}
+
+	sz := 1
+	for _, v := range strings.Split(s[1], ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > 0 && sz > math.MaxInt32/n {
+			return typ, false, nil, false
+		}
+		shape, sz = append(shape, n), sz*n
+	}
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+	return typ, f[1] == "True", shape, true
+}
+
+// WriteNpy writes the array to w in the numpy .npy format, in row-major order with little-endian elements.
+// Arrays in error return their error, and errors from w are returned unchanged.
+func (a *Array[T]) WriteNpy(w io.Writer) error {
+	if a.HasErr() {
+		return a.getErr()
+	}
+
+	k := kern[T]()
+	code := npyKinds[k.name]
+	typ, _ := parseNpyType("<", code[:1], code[1:])
+	descr := "<" + code
+	if typ.kind == 'b' {
+		descr = "|" + code
+	}
+
+	sh := make([]string, len(a.shape))
+	for i, v := range a.shape {
+		sh[i] = strconv.Itoa(v)
+	}
+	shape := strings.Join(sh, ", ")
+	if len(sh) == 1 {
+		shape += ","
+	}
+
+	// The header is padded with spaces, so the data starts on a 64 byte boundary.
+	hdr := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", descr, shape)
+	pre := []byte(npyMagic + "\x01\x00\x00\x00")
+	if len(hdr)+len(pre) >= math.MaxUint16 {
+		pre = []byte(npyMagic + "\x02\x00\x00\x00\x00\x00")
+	}
+	hdr += strings.Repeat(" ", 63-(len(pre)+len(hdr))%64) + "\n"
+	if len(pre) == 10 {
+		binary.LittleEndian.PutUint16(pre[8:], uint16(len(hdr)))
+	} else {
+		binary.LittleEndian.PutUint32(pre[8:], uint32(len(hdr)))
+	}
+
+	d := a.flat()
+	buf := make([]byte, len(pre)+len(hdr)+len(d)*typ.size)
+	n := copy(buf, pre)
+	n += copy(buf[n:], hdr)
+	for i, v := range d {
+		typ.put(buf[n+i*typ.size:], k.load(v))
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// LoadNpz reads the arrays in a numpy .npz archive, which is a zip archive of .npy files.
+// Arrays are named by their file names, without the .npy extension.
+//
+// Each array is read with ReadNpy, so arrays that can't be decoded hold a FormatError.
+// A FormatError is returned when r doesn't hold a zip archive.
+func LoadNpz[T Elem](r io.ReaderAt, size int64) (map[string]*Array[T], error) {
+	z, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, FormatError
+	}
+
+	arrays := make(map[string]*Array[T], len(z.File))
+	for _, f := range z.File {
+		name := strings.TrimSuffix(f.Name, ".npy")
+		rc, err := f.Open()
+		if err != nil {
+			arrays[name] = npyErr[T]("LoadNpz", "File: %s  Error: %v", f.Name, err)
+			continue
+		}
+		arrays[name] = ReadNpy[T](rc)
+		rc.Close()
+	}
+	return arrays, nil
+}
+
+// SaveNpz writes the arrays to w as an uncompressed numpy .npz archive, with each array
+// written by WriteNpy to a file named by its key and the .npy extension.
+// The first error from an array or w is returned.
+func SaveNpz[T Elem](w io.Writer, arrays map[string]*Array[T]) error {
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	z := zip.NewWriter(w)
+	var buf bytes.Buffer
+	for _, name := range names {
+		buf.Reset()
+		if err := arrays[name].WriteNpy(&buf); err != nil {
+			return err
+		}
+		f, err := z.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		if _, err = f.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return z.Close()
+}
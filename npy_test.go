@@ -0,0 +1,200 @@
+package numgo
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+)
+
+// npyFile builds a version 1.0 .npy file from a header and raw data.
+func npyFile(hdr string, data ...interface{}) []byte {
+	var b bytes.Buffer
+	b.WriteString(npyMagic + "\x01\x00")
+	binary.Write(&b, binary.LittleEndian, uint16(len(hdr)))
+	b.WriteString(hdr)
+	for _, d := range data {
+		binary.Write(&b, binary.BigEndian, d)
+	}
+	return b.Bytes()
+}
+
+func TestWriteNpy(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	if err := Arange(6).Reshape(2, 3).WriteNpy(&b); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	hdr := "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }"
+	if d := b.Bytes(); string(d[:8]) != "\x93NUMPY\x01\x00" || !strings.HasPrefix(string(d[10:]), hdr) ||
+		(10+int(binary.LittleEndian.Uint16(d[8:])))%64 != 0 || d[9+binary.LittleEndian.Uint16(d[8:])] != '\n' ||
+		len(d) != 10+int(binary.LittleEndian.Uint16(d[8:]))+48 || math.Float64frombits(binary.LittleEndian.Uint64(d[len(d)-8:])) != 5 {
+		t.Log("Incorrect file", b.String())
+		t.Fail()
+	}
+
+	b.Reset()
+	if err := NewArrayB([]bool{true, false, true}, 3).WriteNpy(&b); err != nil ||
+		!strings.Contains(b.String(), "{'descr': '|b1', 'fortran_order': False, 'shape': (3,), }") || !bytes.HasSuffix(b.Bytes(), []byte{1, 0, 1}) {
+		t.Log("Incorrect bool file", b.String(), err)
+		t.Fail()
+	}
+
+	if err := Arange(4).Reshape(3).WriteNpy(&b); err != ReshapeError {
+		t.Log("Expected ReshapeError, received", err)
+		t.Fail()
+	}
+}
+
+func TestReadNpy(t *testing.T) {
+	t.Parallel()
+	// Arrays of each element type are read back unchanged.
+	var b bytes.Buffer
+	for i, v := range []struct {
+		write func() error
+		read  func() (string, error)
+		exp   string
+	}{
+		{
+			func() error { return Arange(24).Reshape(2, 3, 4).SubArr(1).WriteNpy(&b) },
+			func() (string, error) { a := ReadNpy[float64](&b); return a.String(), a.GetErr() },
+			Arange(12, 23).Reshape(3, 4).String(),
+		},
+		{
+			func() error { return NewArray([]float32{1.5, float32(math.Inf(-1))}, 2).WriteNpy(&b) },
+			func() (string, error) { a := ReadNpy[float32](&b); return a.String(), a.GetErr() },
+			NewArray([]float32{1.5, float32(math.Inf(-1))}, 2).String(),
+		},
+		{
+			func() error { return NewArray([]int16{-300, 2, 7, math.MaxInt16}, 2, 2).WriteNpy(&b) },
+			func() (string, error) { a := ReadNpy[int16](&b); return a.String(), a.GetErr() },
+			NewArray([]int16{-300, 2, 7, math.MaxInt16}, 2, 2).String(),
+		},
+		{
+			func() error { return NewArray([]uint64{math.MaxUint64, 0}, 2).WriteNpy(&b) },
+			func() (string, error) { a := ReadNpy[uint64](&b); return a.String(), a.GetErr() },
+			NewArray([]uint64{math.MaxUint64, 0}, 2).String(),
+		},
+		{
+			func() error { return NewArray([]complex128{1 + 2i, -3i}, 2).WriteNpy(&b) },
+			func() (string, error) { a := ReadNpy[complex128](&b); return a.String(), a.GetErr() },
+			NewArray([]complex128{1 + 2i, -3i}, 2).String(),
+		},
+		{
+			func() error { return NewArray([]complex64{1 + 2i, -3i}, 1, 2).WriteNpy(&b) },
+			func() (string, error) { a := ReadNpy[complex64](&b); return a.String(), a.GetErr() },
+			NewArray([]complex64{1 + 2i, -3i}, 1, 2).String(),
+		},
+		{
+			func() error { return NewArrayB([]bool{true, false, true, true}, 4).WriteNpy(&b) },
+			func() (string, error) { a := ReadNpy[bool](&b); return a.String(), a.GetErr() },
+			NewArrayB([]bool{true, false, true, true}, 4).String(),
+		},
+		{
+			func() error { return NewArray([]int8{-1, 0, 3}, 3).WriteNpy(&b) },
+			func() (string, error) { a := ReadNpy[float64](&b); return a.String(), a.GetErr() },
+			NewArray64([]float64{-1, 0, 3}).String(),
+		},
+		{
+			func() error { return NewArray64([]float64{0, 0.5, 2}).WriteNpy(&b) },
+			func() (string, error) { a := ReadNpy[bool](&b); return a.String(), a.GetErr() },
+			NewArrayB([]bool{false, true, true}, 3).String(),
+		},
+	} {
+		b.Reset()
+		if err := v.write(); err != nil {
+			t.Log("Test", i, "Unexpected error", err)
+			t.Fail()
+			continue
+		}
+		if s, err := v.read(); err != nil || s != v.exp {
+			t.Log("Test", i, "Expected", v.exp, "Received", s, err)
+			t.Fail()
+		}
+	}
+
+	// Big-endian data in Fortran order.
+	f := npyFile("{'descr': '>i4', 'fortran_order': True, 'shape': (2, 3), }\n", []int32{1, 4, 2, 5, 3, -6})
+	if a := ReadNpy[float64](bytes.NewReader(f)); !a.Equals(NewArray64([]float64{1, 2, 3, 4, 5, -6}, 2, 3)).All().At(0) {
+		t.Log("Fortran order failed", a)
+		t.Fail()
+	}
+	f = npyFile("{'descr': '>f8', 'fortran_order': True, 'shape': (2, 2, 2), }\n", []float64{0, 4, 2, 6, 1, 5, 3, 7})
+	if a := ReadNpy[float64](bytes.NewReader(f)); !a.Equals(Arange(8).Reshape(2, 2, 2)).All().At(0) {
+		t.Log("3-D Fortran order failed", a)
+		t.Fail()
+	}
+	f = npyFile("{'descr': '<u2', 'fortran_order': False, 'shape': (), }\n", []byte{7, 1})
+	if a := ReadNpy[uint16](bytes.NewReader(f)); a.HasErr() || len(a.shape) != 1 || a.At(0) != 263 {
+		t.Log("0-D array failed", a)
+		t.Fail()
+	}
+
+	for i, v := range [][]byte{
+		nil,
+		[]byte("\x93NUMPZ\x01\x00\x00\x00"),
+		[]byte("\x93NUMPY\x04\x00\x00\x00"),
+		npyFile("{'descr': '<U3', 'fortran_order': False, 'shape': (2,), }\n"),
+		npyFile("{'descr': '<f2', 'fortran_order': False, 'shape': (2,), }\n"),
+		npyFile("{'descr': '<f8', 'shape': (2,), }\n"),
+		npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (2, -1), }\n"),
+		npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (100000, 100000), }\n"),
+		npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (2,), }\n", []float64{1}),
+		npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (2,), }"[:30]),
+		npyFile(strings.Repeat(" ", npyMaxHeader+1)),
+		[]byte("\x93NUMPY\x02\x00\xff\xff\xff\x7f{'descr'"),
+		[]byte("\x93NUMPY\x01\x00\x40\x00{'descr': '<f8'"),
+	} {
+		if e := ReadNpy[float64](bytes.NewReader(v)).GetErr(); e != FormatError {
+			t.Log("Test", i, "Expected FormatError, received", e)
+			t.Fail()
+		}
+	}
+}
+
+func TestNpz(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	in := map[string]*Array64{
+		"x":   Arange(6).Reshape(2, 3),
+		"y/z": NewArray64([]float64{math.NaN(), 1}),
+	}
+	if err := SaveNpz(&b, in); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	out, err := LoadNpz[float64](bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil || len(out) != 2 {
+		t.Fatal("Load failed", out, err)
+	}
+	if x := out["x"]; !x.Equals(Arange(6).Reshape(2, 3)).All().At(0) {
+		t.Log("Incorrect array x", x)
+		t.Fail()
+	}
+	if y := out["y/z"]; !sameNaN(y, NewArray64([]float64{math.NaN(), 1})) {
+		t.Log("Incorrect array y/z", y)
+		t.Fail()
+	}
+
+	// Files that can't be decoded are held as errors.
+	b.Reset()
+	z := zip.NewWriter(&b)
+	w, _ := z.Create("bad.npy")
+	w.Write([]byte("not an array"))
+	z.Close()
+	if out, err := LoadNpz[bool](bytes.NewReader(b.Bytes()), int64(b.Len())); err != nil || out["bad"].GetErr() != FormatError {
+		t.Log("Expected FormatError array, received", out, err)
+		t.Fail()
+	}
+
+	if _, err := LoadNpz[float64](strings.NewReader("not a zip"), 9); err != FormatError {
+		t.Log("Expected FormatError, received", err)
+		t.Fail()
+	}
+	if err := SaveNpz(&b, map[string]*Array64{"x": Arange(2).Reshape(3)}); err != ReshapeError {
+		t.Log("Expected ReshapeError, received", err)
+		t.Fail()
+	}
+}
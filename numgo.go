@@ -3,29 +3,67 @@ package numgo
 import (
 	"encoding/json"
 	"fmt"
-	"math"
 	"math/rand"
 	"runtime"
 	"strings"
 )
 
-// Array64 is an n-dimensional array of float64 data
-type Array64 struct {
+// Array is an n-dimensional array of elements of type T.
+//
+// All methods are available for every element type.  Arithmetic on bool
+// arrays is not supported, and will generate a TypeError.
+type Array[T Elem] struct {
 	shape        []int
 	strides      []int
-	data         []float64
+	data         []T
+	offset       int
+	shared       bool
 	err          error
 	debug, stack string
 }
 
+// Array64 is an n-dimensional array of float64 data
+type Array64 = Array[float64]
+
+// Arrayb is an n-dimensional array of boolean values
+type Arrayb = Array[bool]
+
+// Array32 is an n-dimensional array of float32 data
+type Array32 = Array[float32]
+
+// ArrayC64 is an n-dimensional array of complex64 data
+type ArrayC64 = Array[complex64]
+
+// ArrayC128 is an n-dimensional array of complex128 data
+type ArrayC128 = Array[complex128]
+
+// Integer arrays
+type (
+	ArrayI8  = Array[int8]
+	ArrayI16 = Array[int16]
+	ArrayI32 = Array[int32]
+	ArrayI64 = Array[int64]
+	ArrayU8  = Array[uint8]
+	ArrayU16 = Array[uint16]
+	ArrayU32 = Array[uint32]
+	ArrayU64 = Array[uint64]
+)
+
 // NewArray64 creates an Array64 object with dimensions given in order from outer-most to inner-most
 // Passing a slice with no shape data will wrap the slice as a 1-D array.
 // All values will default to zero.  Passing nil as the data parameter creates an empty array.
 func NewArray64(data []float64, shape ...int) (a *Array64) {
+	return NewArray(data, shape...)
+}
+
+// NewArray creates an Array object with dimensions given in order from outer-most to inner-most
+// Passing a slice with no shape data will wrap the slice as a 1-D array.
+// All values will default to zero.  Passing nil as the data parameter creates an empty array.
+func NewArray[T Elem](data []T, shape ...int) (a *Array[T]) {
 	if len(shape) == 0 {
 		switch {
 		case data != nil:
-			return &Array64{
+			return &Array[T]{
 				shape:   []int{len(data)},
 				strides: []int{len(data), 1},
 				data:    data,
@@ -34,10 +72,10 @@ func NewArray64(data []float64, shape ...int) (a *Array64) {
 				stack:   "",
 			}
 		default:
-			return &Array64{
+			return &Array[T]{
 				shape:   []int{0},
 				strides: []int{0, 0},
-				data:    []float64{},
+				data:    []T{},
 				err:     nil,
 				debug:   "",
 				stack:   "",
@@ -49,7 +87,7 @@ func NewArray64(data []float64, shape ...int) (a *Array64) {
 	sh := make([]int, len(shape))
 	for _, v := range shape {
 		if v < 0 {
-			a = &Array64{err: NegativeAxis}
+			a = &Array[T]{err: NegativeAxis}
 			if debug {
 				a.debug = fmt.Sprintf("Negative axis length received by Create: %v", shape)
 				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
@@ -60,10 +98,10 @@ func NewArray64(data []float64, shape ...int) (a *Array64) {
 	}
 	copy(sh, shape)
 
-	a = &Array64{
+	a = &Array[T]{
 		shape:   sh,
 		strides: make([]int, len(shape)+1),
-		data:    make([]float64, sz),
+		data:    make([]T, sz),
 		err:     nil,
 		debug:   "",
 		stack:   "",
@@ -82,15 +120,20 @@ func NewArray64(data []float64, shape ...int) (a *Array64) {
 
 // Internal function to create using the shape of another array
 func newArray64(shape ...int) (a *Array64) {
+	return newArray[float64](shape...)
+}
+
+// Internal function to create using the shape of another array
+func newArray[T Elem](shape ...int) (a *Array[T]) {
 	var sz int = 1
 	for _, v := range shape {
 		sz *= v
 	}
 
-	a = &Array64{
+	a = &Array[T]{
 		shape:   shape,
 		strides: make([]int, len(shape)+1),
-		data:    make([]float64, sz),
+		data:    make([]T, sz),
 		err:     nil,
 		debug:   "",
 		stack:   "",
@@ -103,6 +146,20 @@ func newArray64(shape ...int) (a *Array64) {
 	return
 }
 
+// Full creates an Array object with dimensions given in order from outer-most to inner-most
+// All elements will be set to the value passed in val.
+func Full[T Elem](val T, shape ...int) (a *Array[T]) {
+	a = NewArray[T](nil, shape...)
+	if a.HasErr() {
+		return
+	}
+
+	for i := range a.data {
+		a.data[i] = val
+	}
+	return
+}
+
 // FullArray64 creates an Array64 object with dimensions given in order from outer-most to inner-most
 // All elements will be set to the value passed in val.
 func FullArray64(val float64, shape ...int) (a *Array64) {
@@ -205,7 +262,7 @@ func Identity(size int) (r *Array64) {
 }
 
 // String Satisfies the Stringer interface for fmt package
-func (a *Array64) String() (s string) {
+func (a *Array[T]) String() (s string) {
 	switch {
 	case a == nil:
 		return "<nil>"
@@ -215,6 +272,8 @@ func (a *Array64) String() (s string) {
 		return "<nil>"
 	case a.strides[0] == 0:
 		return "[]"
+	case !a.contig():
+		return a.C().String()
 	case len(a.shape) == 1:
 		return fmt.Sprint(a.data)
 	}
@@ -254,7 +313,7 @@ func (a *Array64) String() (s string) {
 // Reshape Changes the size of the array axes.  Values are not changed or moved.
 // This must not change the size of the array.
 // Incorrect dimensions will return a nil pointer
-func (a *Array64) Reshape(shape ...int) *Array64 {
+func (a *Array[T]) Reshape(shape ...int) *Array[T] {
 	if a.HasErr() || len(shape) == 0 {
 		return a
 	}
@@ -274,7 +333,7 @@ func (a *Array64) Reshape(shape ...int) *Array64 {
 	}
 	copy(sh, shape)
 
-	if sz != len(a.data) {
+	if sz != a.strides[0] {
 		a.err = ReshapeError
 		if debug {
 			a.debug = fmt.Sprintf("Reshape() can not change data size.  Dimensions: %v reshape: %v", a.shape, shape)
@@ -283,6 +342,9 @@ func (a *Array64) Reshape(shape ...int) *Array64 {
 		return a
 	}
 
+	if !a.contig() {
+		a.own()
+	}
 	a.strides = make([]int, len(sh)+1)
 	tmp := 1
 	for i := len(a.strides) - 1; i > 0; i-- {
@@ -295,87 +357,53 @@ func (a *Array64) Reshape(shape ...int) *Array64 {
 	return a
 }
 
-// encode is used to prepare data for MarshalJSON that isn't JSON defined.
-func (a *Array64) encode() (inf, nan []int64, err int8) {
-	for k, v := range a.data {
-		switch {
-		case math.IsNaN(float64(v)):
-			a.data[k] = 0
-			nan = append(nan, int64(k+1))
-		case math.IsInf(float64(v), 1):
-			a.data[k] = 0
-			inf = append(inf, int64(k+1))
-		case math.IsInf(float64(v), -1):
-			a.data[k] = 0
-			inf = append(inf, int64(-(k + 1)))
-		}
-	}
-
-	err = encodeErr(a.err)
-	return
-}
-
 // MarshalJSON fulfills the json.Marshaler Interface for encoding data.
 // Custom Unmarshaler is needed to encode/send unexported values.
-func (a *Array64) MarshalJSON() ([]byte, error) {
+//
+// Floating point values that aren't JSON defined are recorded by position in the inf and nan fields.
+func (a *Array[T]) MarshalJSON() ([]byte, error) {
 	t := a.C()
 
-	inf, nan, err := t.encode()
+	data, inf, nan := kern[T]().encode(t.data)
 	return json.Marshal(struct {
-		Shape []int     `json:"shape"`
-		Data  []float64 `json:"data"`
-		Inf   []int64   `json:"inf,omitempty"`
-		Nan   []int64   `json:"nan,omitempty"`
-		Err   int8      `json:"err,omitempty"`
+		Shape []int       `json:"shape"`
+		Data  interface{} `json:"data"`
+		Inf   []int64     `json:"inf,omitempty"`
+		Nan   []int64     `json:"nan,omitempty"`
+		Err   int8        `json:"err,omitempty"`
 	}{
 		Shape: t.shape,
-		Data:  t.data,
+		Data:  data,
 		Inf:   inf,
 		Nan:   nan,
-		Err:   err,
+		Err:   encodeErr(t.err),
 	})
 }
 
-// decode is used to build Array from UnmarshalJSON for values that aren't JSON defined.
-func (a *Array64) decode(i, n []int64, err int8) {
-	inf, nInf := math.Inf(1), math.Inf(-1)
-	nan := math.NaN()
-
-	for _, v := range n {
-		a.data[v-1] = nan
-	}
-
-	for _, v := range i {
-		if v-1 >= 0 {
-			a.data[v-1] = inf
-		} else {
-			a.data[-v-1] = nInf
-		}
-	}
-	a.err = decodeErr(err)
-}
-
 // UnmarshalJSON fulfills the json.Unmarshaler interface for decoding data.
 // Custom Unmarshaler is needed to load/decode unexported values and build strides.
-func (a *Array64) UnmarshalJSON(b []byte) error {
+func (a *Array[T]) UnmarshalJSON(b []byte) error {
 	tmpA := new(struct {
-		Shape []int     `json:"shape"`
-		Data  []float64 `json:"data"`
-		Inf   []int64   `json:"inf,omitempty"`
-		Nan   []int64   `json:"nan,omitempty"`
-		Err   int8      `json:"err,omitempty"`
+		Shape []int           `json:"shape"`
+		Data  json.RawMessage `json:"data"`
+		Inf   []int64         `json:"inf,omitempty"`
+		Nan   []int64         `json:"nan,omitempty"`
+		Err   int8            `json:"err,omitempty"`
 	})
 
 	err := json.Unmarshal(b, tmpA)
 
 	a.shape = tmpA.Shape
-	a.data = tmpA.Data
-	a.decode(tmpA.Inf, tmpA.Nan, tmpA.Err)
+	a.data, a.offset, a.shared = nil, 0, false
+	if err == nil && len(tmpA.Data) > 0 {
+		a.data, err = kern[T]().decode(tmpA.Data, tmpA.Inf, tmpA.Nan)
+	}
+	a.err = decodeErr(tmpA.Err)
 
 	if a.data == nil && a.err == nil {
 		a.err = NilError
 		a.strides = nil
-		return nil
+		return err
 	}
 
 	a.strides = make([]int, len(a.shape)+1)
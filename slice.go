@@ -0,0 +1,262 @@
+package numgo
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+)
+
+// None can be used as the start or stop value of a range, in the same way as
+// Python's None in a slice.  The range will extend to the end of the axis in
+// the direction of the step.
+const None = math.MinInt32
+
+type idxKind uint8
+
+const (
+	idxRange idxKind = iota
+	idxSingle
+	idxEllipsis
+	idxNewAxis
+)
+
+// Index selects elements along an axis in Slice and SetSlice calls.
+//
+// Use R for ranges, Idx for a single index, and the All, Ellipsis and NewAxis markers.
+type Index struct {
+	kind              idxKind
+	start, stop, step int
+}
+
+var (
+	// All selects every element along an axis.  Equivalent to ':' in numpy.
+	All = Index{kind: idxRange, start: None, stop: None, step: 1}
+	// Ellipsis expands to as many All indices as are needed to index every axis.
+	// Only one Ellipsis can be used in a call.  Equivalent to '...' in numpy.
+	Ellipsis = Index{kind: idxEllipsis}
+	// NewAxis inserts a new axis of length 1.  Equivalent to numpy.newaxis.
+	NewAxis = Index{kind: idxNewAxis}
+)
+
+// R creates a range index from start up to, but not including, stop.
+// The step defaults to 1, and values past the first step are ignored.
+//
+// Negative start and stop values count back from the end of the axis.
+// Out of range values are clipped to the axis, and None selects the end of the axis.
+// Negative steps will traverse the axis in reverse.
+func R(start, stop int, step ...int) Index {
+	st := 1
+	if len(step) > 0 {
+		st = step[0]
+	}
+	return Index{kind: idxRange, start: start, stop: stop, step: st}
+}
+
+// Idx creates an index selecting a single element along an axis.
+// The axis is removed from the result.  Negative values count back from the end of the axis.
+func Idx(i int) Index {
+	return Index{kind: idxSingle, start: i}
+}
+
+// Slice returns a view of the array selected by the indices, one per axis.
+// Missing indices at the end select the whole axis.
+//
+//	a.Slice(R(1, 10, 2), All, Idx(-1))  // numpy: a[1:10:2, :, -1]
+//	a.Slice(Ellipsis, R(None, None, -1)) // numpy: a[..., ::-1]
+//	a.Slice(NewAxis, Idx(0))             // numpy: a[np.newaxis, 0]
+//
+// The returned array shares data with the source array, so changes to either array will be seen in both.
+func (a *Array[T]) Slice(index ...Index) *Array[T] {
+	off, sh, st, ok := a.slice(index, "Slice")
+	if !ok {
+		return a
+	}
+	return a.view(off, sh, st)
+}
+
+// SetSlice sets the elements selected by the indices to the values in vals.
+// Indices are handled the same way as Slice, and vals must be able to broadcast
+// to the shape of the selection.
+//
+// Source Array is returned, for function-chaining design.
+func (a *Array[T]) SetSlice(vals *Array[T], index ...Index) *Array[T] {
+	switch {
+	case a.HasErr():
+		return a
+	case vals == nil:
+		a.err = NilError
+		if debug {
+			a.debug = "Array received by SetSlice() is a Nil pointer."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	case vals.HasErr():
+		a.err = vals.getErr()
+		if debug {
+			a.debug = "Array received by SetSlice() is in error."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	}
+
+	off, sh, st, ok := a.slice(index, "SetSlice")
+	if !ok {
+		return a
+	}
+
+	if b, ok := broadcastShape(sh, vals.shape); !ok || !equalShape(b, sh) {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Array received by SetSlice() can not be broadcast.  Slice shape: %v  Vals shape: %v", sh, vals.shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	}
+
+	scatter(a.data, vals.valCopy(sh), off, sh, st)
+	return a
+}
+
+// slice calculates the data offset, shape and element strides selected by the indices.
+func (a *Array[T]) slice(index []Index, mthd string) (off int, sh, st []int, ok bool) {
+	if a.HasErr() {
+		return 0, nil, nil, false
+	}
+
+	// Count the axes used, to expand the Ellipsis.
+	used, ell := 0, false
+	for _, v := range index {
+		switch v.kind {
+		case idxRange, idxSingle:
+			used++
+		case idxEllipsis:
+			if ell {
+				a.err = InvIndexError
+				if debug {
+					a.debug = fmt.Sprintf("Multiple Ellipsis indices received by %s().  Index: %v", mthd, index)
+					a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+				}
+				return 0, nil, nil, false
+			}
+			ell = true
+		}
+	}
+	if used > len(a.shape) {
+		a.err = InvIndexError
+		if debug {
+			a.debug = fmt.Sprintf("Incorrect number of indicies received by %s().  Shape: %v  Index: %v", mthd, a.shape, index)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return 0, nil, nil, false
+	}
+
+	off = a.offset
+	sh, st = make([]int, 0, len(a.shape)+len(index)), make([]int, 0, len(a.shape)+len(index))
+	ax := 0
+	for _, v := range index {
+		switch v.kind {
+		case idxNewAxis:
+			sh, st = append(sh, 1), append(st, 0)
+		case idxEllipsis:
+			for n := len(a.shape) - used; n > 0; n, ax = n-1, ax+1 {
+				sh, st = append(sh, a.shape[ax]), append(st, a.strides[ax+1])
+			}
+		case idxSingle:
+			i := v.start
+			if i < 0 {
+				i += a.shape[ax]
+			}
+			if i < 0 || i >= a.shape[ax] {
+				a.err = IndexError
+				if debug {
+					a.debug = fmt.Sprintf("Index received by %s() does not exist shape: %v index: %v", mthd, a.shape, index)
+					a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+				}
+				return 0, nil, nil, false
+			}
+			off += i * a.strides[ax+1]
+			ax++
+		case idxRange:
+			if v.step == 0 {
+				a.err = InvIndexError
+				if debug {
+					a.debug = fmt.Sprintf("Zero step received by %s().  Index: %v", mthd, index)
+					a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+				}
+				return 0, nil, nil, false
+			}
+			start, n := v.span(a.shape[ax])
+			if n > 0 {
+				off += start * a.strides[ax+1]
+			}
+			sh, st = append(sh, n), append(st, v.step*a.strides[ax+1])
+			ax++
+		}
+	}
+
+	for ; ax < len(a.shape); ax++ {
+		sh, st = append(sh, a.shape[ax]), append(st, a.strides[ax+1])
+	}
+	return off, sh, st, true
+}
+
+// span calculates the first index and number of elements selected by a range on an axis of length ln.
+// This follows the rules of Python slices.
+func (r Index) span(ln int) (start, n int) {
+	if r.step > 0 {
+		start, stop := bound(r.start, 0, 0, ln, ln), bound(r.stop, ln, 0, ln, ln)
+		if stop > start {
+			n = (stop - start + r.step - 1) / r.step
+		}
+		return start, n
+	}
+
+	start, stop := bound(r.start, ln-1, -1, ln-1, ln), bound(r.stop, -1, -1, ln-1, ln)
+	if start > stop {
+		n = (start - stop - r.step - 1) / -r.step
+	}
+	return start, n
+}
+
+// bound resolves a range bound on an axis of length ln.  None is replaced by def,
+// negative values count back from the end of the axis, and the result is limited to [lo, hi].
+func bound(v, def, lo, hi, ln int) int {
+	if v == None {
+		return def
+	}
+	if v < 0 {
+		v += ln
+	}
+	switch {
+	case v < lo:
+		return lo
+	case v > hi:
+		return hi
+	}
+	return v
+}
+
+// String satisfies the Stringer interface, using numpy's indexing notation.
+func (r Index) String() string {
+	bnd := func(v int) string {
+		if v == None {
+			return ""
+		}
+		return fmt.Sprint(v)
+	}
+
+	switch r.kind {
+	case idxSingle:
+		return fmt.Sprint(r.start)
+	case idxEllipsis:
+		return "..."
+	case idxNewAxis:
+		return "newaxis"
+	case idxRange:
+		if r.step == 1 {
+			return bnd(r.start) + ":" + bnd(r.stop)
+		}
+		return bnd(r.start) + ":" + bnd(r.stop) + ":" + fmt.Sprint(r.step)
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+package numgo
+
+import "testing"
+
+func init() {
+	debug = true
+}
+
+func TestSlice(t *testing.T) {
+	a := Arange(60).Reshape(3, 4, 5)
+	for i, v := range []struct {
+		idx   []Index
+		shape []int
+		res   []float64
+		err   error
+	}{
+		{[]Index{}, []int{3, 4, 5}, nil, nil},
+		{[]Index{Idx(1), Idx(2)}, []int{5}, []float64{30, 31, 32, 33, 34}, nil},
+		{[]Index{Idx(-1), Idx(-1), Idx(-1)}, []int{}, []float64{59}, nil},
+		{[]Index{Idx(0), All, Idx(-1)}, []int{4}, []float64{4, 9, 14, 19}, nil},
+		{[]Index{Idx(0), Idx(0), R(1, 10, 2)}, []int{2}, []float64{1, 3}, nil},
+		{[]Index{Idx(0), Idx(0), R(None, None, -1)}, []int{5}, []float64{4, 3, 2, 1, 0}, nil},
+		{[]Index{Idx(0), Idx(0), R(-2, None)}, []int{2}, []float64{3, 4}, nil},
+		{[]Index{Idx(0), Idx(0), R(3, 0, -2)}, []int{2}, []float64{3, 1}, nil},
+		{[]Index{Idx(0), Idx(0), R(-10, 10)}, []int{5}, []float64{0, 1, 2, 3, 4}, nil},
+		{[]Index{Idx(0), Idx(0), R(4, -10, -3)}, []int{2}, []float64{4, 1}, nil},
+		{[]Index{Idx(0), Idx(0), R(3, 3)}, []int{0}, []float64{}, nil},
+		{[]Index{Idx(0), Idx(0), R(1, 4, -1)}, []int{0}, []float64{}, nil},
+		{[]Index{Ellipsis, Idx(1)}, []int{3, 4}, nil, nil},
+		{[]Index{Idx(2), Ellipsis, Idx(1)}, []int{4}, []float64{41, 46, 51, 56}, nil},
+		{[]Index{Ellipsis}, []int{3, 4, 5}, nil, nil},
+		{[]Index{NewAxis, Idx(0), NewAxis, Idx(1)}, []int{1, 1, 5}, []float64{5, 6, 7, 8, 9}, nil},
+		{[]Index{R(None, None, 2), R(1, 3), Idx(0)}, []int{2, 2}, []float64{5, 10, 45, 50}, nil},
+		{[]Index{Idx(3)}, nil, nil, IndexError},
+		{[]Index{Idx(-4)}, nil, nil, IndexError},
+		{[]Index{Idx(0), Idx(0), Idx(0), Idx(0)}, nil, nil, InvIndexError},
+		{[]Index{Ellipsis, Ellipsis}, nil, nil, InvIndexError},
+		{[]Index{R(0, 2, 0)}, nil, nil, InvIndexError},
+	} {
+		b := a.Slice(v.idx...)
+		if e := a.GetErr(); e != v.err {
+			t.Log("Test", i, v.idx, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err != nil {
+			continue
+		}
+		if !equalShape(b.shape, v.shape) {
+			t.Log("Test", i, v.idx, "Expected shape", v.shape, "Got", b.shape)
+			t.Fail()
+			continue
+		}
+		if v.res != nil {
+			d := b.flat()
+			for j := range v.res {
+				if d[j] != v.res[j] {
+					t.Log("Test", i, v.idx, "Expected", v.res, "Got", d)
+					t.Fail()
+					break
+				}
+			}
+		}
+	}
+
+	// Slices are views of the source data
+	b := a.Slice(Idx(1), R(None, None, 3), R(4, None, -2))
+	b.MultC(-1)
+	for _, v := range [][]int{{1, 0, 4}, {1, 0, 2}, {1, 0, 0}, {1, 3, 4}, {1, 3, 2}, {1, 3, 0}} {
+		if e := -float64(v[0]*20 + v[1]*5 + v[2]); a.At(v...) != e {
+			t.Log("View change at", v, "Expected", e, "Got", a.At(v...))
+			t.Fail()
+		}
+	}
+	if a.At(1, 0, 1) != 21 || a.At(1, 1, 0) != 25 {
+		t.Log("View changed elements outside the slice", a)
+		t.Fail()
+	}
+
+	c := b.Slice(Idx(-1), R(None, None, -1))
+	if !c.Equals(NewArray64([]float64{-35, -37, -39})).All().At(0) {
+		t.Log("Slice of slice failed", c)
+		t.Fail()
+	}
+
+	var n *Array64
+	if n.Slice(All).GetErr() != NilError {
+		t.Log("Nil array not caught")
+		t.Fail()
+	}
+}
+
+func TestSetSlice(t *testing.T) {
+	for i, v := range []struct {
+		vals *Array64
+		idx  []Index
+		res  []float64
+		err  error
+	}{
+		{NewArray64([]float64{1, 2}), []Index{All, R(1, 3)}, []float64{0, 1, 2, 0, 0, 1, 2, 0, 0, 1, 2, 0}, nil},
+		{NewArray64([]float64{1, 2}), []Index{R(1, None), Idx(0)}, []float64{0, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0}, nil},
+		{FullArray64(5, 1), []Index{Ellipsis, R(None, None, -3)}, []float64{5, 0, 0, 5, 5, 0, 0, 5, 5, 0, 0, 5}, nil},
+		{NewArray64([]float64{1, 2, 3}), []Index{Idx(-1), R(3, 0, -1)}, []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 2, 1}, nil},
+		{NewArray64([]float64{1, 2}, 1, 2), []Index{R(0, 2), NewAxis, R(0, 2)}, []float64{1, 2, 0, 0, 1, 2, 0, 0, 0, 0, 0, 0}, nil},
+		{NewArray64([]float64{1, 2, 3}), []Index{All, R(1, 3)}, nil, ShapeError},
+		{NewArray64([]float64{1, 2}), []Index{Idx(4)}, nil, IndexError},
+		{nil, []Index{All}, nil, NilError},
+		{&Array64{err: InvIndexError}, []Index{All}, nil, InvIndexError},
+	} {
+		a := NewArray64(nil, 3, 4)
+		a.SetSlice(v.vals, v.idx...)
+		if e := a.GetErr(); e != v.err {
+			t.Log("Test", i, v.idx, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !a.Equals(NewArray64(v.res, 3, 4)).All().At(0) {
+			t.Log("Test", i, v.idx, "Expected", v.res, "Got", a)
+			t.Fail()
+		}
+	}
+
+	// Overlapping source and destination
+	a := Arange(5)
+	a.SetSlice(a.Slice(R(None, None, -1)), All)
+	if !a.Equals(NewArray64([]float64{4, 3, 2, 1, 0})).All().At(0) {
+		t.Log("Overlapping SetSlice failed", a)
+		t.Fail()
+	}
+}
+
+func TestIndexString(t *testing.T) {
+	for i, v := range []struct {
+		idx Index
+		s   string
+	}{
+		{All, ":"},
+		{Ellipsis, "..."},
+		{NewAxis, "newaxis"},
+		{Idx(-2), "-2"},
+		{R(1, 5), "1:5"},
+		{R(None, 5, 2), ":5:2"},
+		{R(None, None, -1), "::-1"},
+	} {
+		if s := v.idx.String(); s != v.s {
+			t.Log("Test", i, "Expected", v.s, "Got", s)
+			t.Fail()
+		}
+	}
+}
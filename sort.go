@@ -0,0 +1,259 @@
+package numgo
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+)
+
+// Side selects which of the possible insertion points SearchSorted returns for values that are already in the array.
+type Side int
+
+const (
+	// Left returns the index of the first matching element.
+	Left Side = iota
+	// Right returns the index after the last matching element.
+	Right
+)
+
+// Sort sorts the elements along an axis in ascending order, in place.
+// NaN values are sorted to the end.
+//
+// Source Array is returned, for function-chaining design.
+func (a *Array[T]) Sort(axis int) *Array[T] {
+	if a.valAxes([]int{axis}, "Sort") {
+		return a
+	}
+
+	ln, st, less := a.shape[axis], a.strides[axis+1], sortLess[T]()
+	buf := make([]T, ln)
+	for _, o := range a.lines(axis) {
+		for i := range buf {
+			buf[i] = a.data[o+i*st]
+		}
+		sort.Slice(buf, func(i, j int) bool { return less(buf[i], buf[j]) })
+		for i, v := range buf {
+			a.data[o+i*st] = v
+		}
+	}
+	return a
+}
+
+// Argsort returns the indices that would sort the elements along an axis.
+// The sort is stable, so equal elements keep their order, and NaN values are sorted to the end.
+// A new array with the shape of the source is returned.
+func (a *Array[T]) Argsort(axis int) *Array64 {
+	if a.valAxes([]int{axis}, "Argsort") {
+		return errTo[float64](a)
+	}
+
+	r, less := newArray64(a.shape...), sortLess[T]()
+	ln, st, rst := a.shape[axis], a.strides[axis+1], r.strides[axis+1]
+	idx, buf, rl := make([]int, ln), make([]T, ln), r.lines(axis)
+	for n, o := range a.lines(axis) {
+		for i := range buf {
+			buf[i], idx[i] = a.data[o+i*st], i
+		}
+		sort.SliceStable(idx, func(i, j int) bool { return less(buf[idx[i]], buf[idx[j]]) })
+		for i, v := range idx {
+			r.data[rl[n]+i*rst] = float64(v)
+		}
+	}
+	return r
+}
+
+// Partition rearranges the elements along an axis, in place, so the element at position kth is in
+// its sorted position.  All smaller elements are moved before it and all larger elements after it,
+// in no particular order.  Negative values of kth count back from the end of the axis.
+//
+// Source Array is returned, for function-chaining design.
+func (a *Array[T]) Partition(kth, axis int) *Array[T] {
+	if a.valAxes([]int{axis}, "Partition") {
+		return a
+	}
+
+	ln, st := a.shape[axis], a.strides[axis+1]
+	if kth < 0 {
+		kth += ln
+	}
+	if kth < 0 || kth >= ln {
+		a.err = IndexError
+		if debug {
+			a.debug = fmt.Sprintf("Index received by Partition() does not exist shape: %v axis: %d kth: %d", a.shape, axis, kth)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	}
+
+	buf, less := make([]T, ln), sortLess[T]()
+	for _, o := range a.lines(axis) {
+		for i := range buf {
+			buf[i] = a.data[o+i*st]
+		}
+		selectK(buf, kth, less)
+		for i, v := range buf {
+			a.data[o+i*st] = v
+		}
+	}
+	return a
+}
+
+// SearchSorted finds the indices where values would be inserted into the sorted 1-D array to maintain the order.
+// The result has the shape of values.  The array must be sorted in ascending order, with NaN values at the end,
+// as Sort leaves it.  A new array is returned.
+func (a *Array[T]) SearchSorted(values *Array[T], side Side) *Array64 {
+	switch {
+	case a.HasErr():
+		return errTo[float64](a)
+	case values == nil:
+		a.err = NilError
+		if debug {
+			a.debug = "Array received by SearchSorted() is a Nil pointer."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	case values.HasErr():
+		a.err = values.getErr()
+		if debug {
+			a.debug = "Array received by SearchSorted() is in error."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	case len(a.shape) != 1:
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("SearchSorted() requires a 1-D array.  Shape: %v", a.shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	}
+
+	d, vd, less := a.flat(), values.flat(), sortLess[T]()
+	r := newArray64(values.shape...)
+	for i, v := range vd {
+		if side == Right {
+			r.data[i] = float64(sort.Search(len(d), func(j int) bool { return less(v, d[j]) }))
+		} else {
+			r.data[i] = float64(sort.Search(len(d), func(j int) bool { return !less(d[j], v) }))
+		}
+	}
+	return r
+}
+
+// Unique returns the sorted unique elements of the array as a 1-D array.
+// All NaN values are treated as equal, and returned as a single NaN at the end.
+func (a *Array[T]) Unique() *Array[T] {
+	if a.HasErr() {
+		return a
+	}
+	u, _, _ := a.unique()
+	return u
+}
+
+// UniqueCounts returns the sorted unique elements of the array, as Unique,
+// along with the number of times each unique element occurs.
+//
+// On error, the source array is returned in both positions.
+func (a *Array[T]) UniqueCounts() (u *Array[T], counts *Array64) {
+	if a.HasErr() {
+		return a, errTo[float64](a)
+	}
+	u, _, counts = a.unique()
+	return u, counts
+}
+
+// UniqueInverse returns the sorted unique elements of the array, as Unique,
+// along with the index into the unique elements of each element in the array.
+// The inverse array has the shape of the source, so u.TakeAlongAxis(inverse.C().Flatten(), 0)
+// rebuilds the flattened array.
+//
+// On error, the source array is returned in both positions.
+func (a *Array[T]) UniqueInverse() (u *Array[T], inverse *Array64) {
+	if a.HasErr() {
+		return a, errTo[float64](a)
+	}
+	u, inverse, _ = a.unique()
+	return u, inverse
+}
+
+// unique calculates the sorted unique elements, inverse indices and counts of the array.
+func (a *Array[T]) unique() (u *Array[T], inv, cnt *Array64) {
+	d, less := a.flat(), sortLess[T]()
+	idx := make([]int, len(d))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return less(d[idx[i]], d[idx[j]]) })
+
+	ud, cd := make([]T, 0, len(d)), make([]float64, 0, len(d))
+	inv = newArray64(a.shape...)
+	for i, v := range idx {
+		// NaN values are sorted to the end, and are all treated as equal.
+		if x := d[v]; i == 0 || (x != ud[len(ud)-1] && !(x != x && ud[len(ud)-1] != ud[len(ud)-1])) {
+			ud, cd = append(ud, x), append(cd, 0)
+		}
+		cd[len(cd)-1]++
+		inv.data[v] = float64(len(ud) - 1)
+	}
+	return NewArray(ud), inv, NewArray64(cd)
+}
+
+// lines calculates the data position of the first element of each 1-D line along an axis.
+// Lines are in row-major order of the remaining axes.
+func (a *Array[T]) lines(axis int) []int {
+	sh := append(append([]int(nil), a.shape[:axis]...), a.shape[axis+1:]...)
+	st := append(append([]int(nil), a.strides[1:axis+1]...), a.strides[axis+2:]...)
+	return offsets(a.offset, sh, st)
+}
+
+// sortLess returns the ordering used to sort arrays of element type T.
+// Values are in ascending order, with NaN values at the end.
+func sortLess[T Elem]() func(x, y T) bool {
+	lt := kern[T]().lt
+	return func(x, y T) bool {
+		return lt(x, y) || x == x && y != y
+	}
+}
+
+// selectK rearranges d so d[k] holds the value it would have if d were sorted by less,
+// with no larger values before it and no smaller values after it.
+func selectK[T any](d []T, k int, less func(x, y T) bool) {
+	lo, hi := 0, len(d)-1
+	for lo < hi {
+		// Median of three pivot value.
+		p, m, q := d[lo], d[lo+(hi-lo)/2], d[hi]
+		if less(m, p) {
+			p, m = m, p
+		}
+		if less(q, m) {
+			m = q
+			if less(m, p) {
+				m = p
+			}
+		}
+
+		// Three-way partition, so runs of equal values don't degrade the search.
+		lt, i, gt := lo, lo, hi
+		for i <= gt {
+			switch {
+			case less(d[i], m):
+				d[lt], d[i] = d[i], d[lt]
+				lt, i = lt+1, i+1
+			case less(m, d[i]):
+				d[gt], d[i] = d[i], d[gt]
+				gt--
+			default:
+				i++
+			}
+		}
+
+		switch {
+		case k < lt:
+			hi = lt - 1
+		case k > gt:
+			lo = gt + 1
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package numgo
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func init() {
+	debug = true
+}
+
+// vals checks the data of a against e, treating NaN values as equal.
+func vals(a *Array64, e []float64) bool {
+	d := a.flat()
+	if len(d) != len(e) {
+		return false
+	}
+	for i := range e {
+		if d[i] != e[i] && !(math.IsNaN(d[i]) && math.IsNaN(e[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSort(t *testing.T) {
+	nan := math.NaN()
+	a := NewArray64([]float64{3, 1, 2, 9, nan, 4, 0, 8, 5, 7, 6, -1}, 3, 4)
+	for i, v := range []struct {
+		a    *Array64
+		axis int
+		res  []float64
+		err  error
+	}{
+		{a, 1, []float64{1, 2, 3, 9, 0, 4, 8, nan, -1, 5, 6, 7}, nil},
+		{a, 0, []float64{3, 1, 0, -1, 5, 4, 2, 8, nan, 7, 6, 9}, nil},
+		{Arange(5, -1), 0, []float64{-1, 0, 1, 2, 3, 4, 5}, nil},
+		{a, 2, nil, IndexError},
+		{a, -1, nil, IndexError},
+		{nil, 0, nil, NilError},
+	} {
+		var b *Array64
+		if v.a != nil {
+			b = v.a.C()
+		}
+		r := b.Sort(v.axis)
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !vals(r, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+		if v.err == nil && r != b {
+			t.Log("Test", i, "Sort did not return the source array")
+			t.Fail()
+		}
+	}
+
+	// Sorting a view sorts the source data
+	c := Arange(12).Reshape(3, 4)
+	cols(c, 2).MultC(-1).Sort(0)
+	if c.At(0, 2) != -10 || c.At(1, 2) != -6 || c.At(2, 2) != -2 || c.At(0, 1) != 1 {
+		t.Log("Sort of view failed", c)
+		t.Fail()
+	}
+}
+
+func TestArgsort(t *testing.T) {
+	nan := math.NaN()
+	a := NewArray64([]float64{3, 1, 2, 1, nan, 0, 5, 0}, 2, 4)
+	for i, v := range []struct {
+		a    *Array64
+		axis int
+		res  []float64
+		err  error
+	}{
+		{a, 1, []float64{1, 3, 2, 0, 1, 3, 2, 0}, nil},
+		{a, 0, []float64{0, 1, 0, 1, 1, 0, 1, 0}, nil},
+		{a.view(0, []int{4, 2}, []int{1, 4}), 0, []float64{1, 1, 3, 3, 2, 2, 0, 0}, nil},
+		{a, 2, nil, IndexError},
+	} {
+		r := v.a.Argsort(v.axis)
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !vals(r, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+
+	// Argsort results select the sorted values with TakeAlongAxis
+	b := Arange(60).Reshape(3, 4, 5).Map(func(f float64) float64 { return math.Mod(f*37, 11) })
+	for ax := 0; ax < 3; ax++ {
+		s := b.TakeAlongAxis(b.Argsort(ax), ax)
+		if !s.Equals(b.C().Sort(ax)).All().At(0) {
+			t.Log("TakeAlongAxis of Argsort on axis", ax, "Got", s)
+			t.Fail()
+		}
+	}
+}
+
+func TestPartition(t *testing.T) {
+	rnd := rand.New(rand.NewSource(7))
+	for _, n := range []int{1, 2, 3, 10, 101} {
+		d := make([]float64, n)
+		for i := range d {
+			d[i] = float64(rnd.Intn(n/2 + 1))
+		}
+		d[rnd.Intn(n)] = math.NaN()
+		s := append([]float64(nil), d...)
+		less := sortLess[float64]()
+		sort.Slice(s, func(i, j int) bool { return less(s[i], s[j]) })
+
+		for k := 0; k < n; k++ {
+			r := NewArray64(append([]float64(nil), d...)).Partition(k, 0)
+			if r.HasErr() {
+				t.Log("Partition", n, k, "error", r.GetErr())
+				t.Fail()
+				continue
+			}
+			p := r.flat()
+			if !vals(NewArray64([]float64{p[k]}), []float64{s[k]}) {
+				t.Log("Partition", n, k, "Expected", s[k], "Got", p[k])
+				t.Fail()
+			}
+			for i := range p {
+				if i < k && less(p[k], p[i]) || i > k && less(p[i], p[k]) {
+					t.Log("Partition", n, k, "out of order at", i, p)
+					t.Fail()
+					break
+				}
+			}
+		}
+	}
+
+	a := NewArray64([]float64{5, 1, 4, 2, 3, 9, 7, 8}, 2, 4).Partition(-2, 1)
+	if a.At(0, 2) != 4 || a.At(0, 3) != 5 || a.At(1, 2) != 8 || a.At(1, 3) != 9 {
+		t.Log("Partition with negative kth failed", a)
+		t.Fail()
+	}
+	if e := Arange(5).Partition(5, 0).GetErr(); e != IndexError {
+		t.Log("Partition expected IndexError, Got", e)
+		t.Fail()
+	}
+	if e := Arange(5).Partition(0, 1).GetErr(); e != IndexError {
+		t.Log("Partition expected IndexError, Got", e)
+		t.Fail()
+	}
+}
+
+func TestSearchSorted(t *testing.T) {
+	a := NewArray64([]float64{1, 2, 2, 3, 5, math.NaN()})
+	v := NewArray64([]float64{0, 2, 4, 5, 6, math.NaN()}, 2, 3)
+	for i, tc := range []struct {
+		a, v *Array64
+		side Side
+		res  []float64
+		err  error
+	}{
+		{a, v, Left, []float64{0, 1, 4, 4, 5, 5}, nil},
+		{a, v, Right, []float64{0, 3, 4, 5, 5, 6}, nil},
+		{a.view(0, []int{3}, []int{2}), NewArray64([]float64{2, 2.5}), Right, []float64{2, 2}, nil},
+		{a, NewArray64(nil), Left, []float64{}, nil},
+		{a.C().Reshape(2, 3), v, Left, nil, ShapeError},
+		{a, nil, Left, nil, NilError},
+		{a, &Array64{err: IndexError}, Left, nil, IndexError},
+	} {
+		r := tc.a.SearchSorted(tc.v, tc.side)
+		if e := r.GetErr(); e != tc.err {
+			t.Log("Test", i, "Expected error", tc.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if tc.err == nil && (!vals(r, tc.res) || tc.v.strides[0] > 0 && !equalShape(r.shape, tc.v.shape)) {
+			t.Log("Test", i, "Expected", tc.res, "Got", r)
+			t.Fail()
+		}
+	}
+}
+
+func TestUnique(t *testing.T) {
+	nan := math.NaN()
+	a := NewArray64([]float64{3, 1, nan, 3, 2, 1, nan, 3}, 2, 4)
+
+	if u := a.Unique(); !vals(u, []float64{1, 2, 3, nan}) {
+		t.Log("Unique Expected [1 2 3 NaN] Got", u)
+		t.Fail()
+	}
+	u, c := a.UniqueCounts()
+	if !vals(u, []float64{1, 2, 3, nan}) || !vals(c, []float64{2, 1, 3, 2}) {
+		t.Log("UniqueCounts Got", u, c)
+		t.Fail()
+	}
+	u, inv := a.UniqueInverse()
+	if !vals(inv, []float64{2, 0, 3, 2, 1, 0, 3, 2}) || !equalShape(inv.shape, a.shape) {
+		t.Log("UniqueInverse Got", u, inv)
+		t.Fail()
+	}
+	if !vals(u.TakeAlongAxis(inv.C().Flatten(), 0), a.flat()) {
+		t.Log("Unique inverse does not rebuild the array")
+		t.Fail()
+	}
+
+	if u := cols(Arange(12).Reshape(4, 3), 0).MultC(0).Unique(); !vals(u, []float64{0}) {
+		t.Log("Unique of view Got", u)
+		t.Fail()
+	}
+	if u := NewArray64(nil).Unique(); u.HasErr() || u.strides[0] != 0 {
+		t.Log("Unique of empty array Got", u)
+		t.Fail()
+	}
+
+	e := &Array64{err: ShapeError}
+	if u, c := e.UniqueCounts(); u.GetErr() != ShapeError || c != e {
+		t.Log("UniqueCounts error not propagated")
+		t.Fail()
+	}
+	var n *Array64
+	if n.Unique().GetErr() != NilError {
+		t.Log("Unique nil array not caught")
+		t.Fail()
+	}
+}
@@ -1,26 +1,26 @@
 package numgo
 
 import (
+	"fmt"
 	"math"
+	"runtime"
 	"sort"
 
-	"github.com/Kunde21/numgo/internal"
+	"github.com/Kunde21/numgo/internal/mat"
 )
 
 // Sum calculates the sum result array along a given axes.
 // Empty call gives the grand sum of all elements.
-func (a *Array64) Sum(axis ...int) (r *Array64) {
+func (a *Array[T]) Sum(axis ...int) (r *Array[T]) {
+	k := kern[T]()
 	switch {
-	case a.valAxis(&axis, "Sum"):
+	case a.valAxis(&axis, "Sum"), a.valOp(k.sum != nil, "Sum"):
 		return a
 	case len(axis) == 0:
-		tot := float64(0)
-		for _, v := range a.data {
-			tot += v
-		}
-		return FullArray64(tot, 1)
+		return Full(k.sum(a.flat()), 1)
 	}
 
+	a.own()
 	sort.IntSlice(axis).Sort()
 	n := make([]int, len(a.shape)-len(axis))
 
@@ -36,13 +36,13 @@ axisR:
 	}
 
 	ln := a.strides[0]
-	for k := 0; k < len(axis); k++ {
-		if a.shape[axis[k]] == 1 {
+	for x := 0; x < len(axis); x++ {
+		if a.shape[axis[x]] == 1 {
 			continue
 		}
-		v, wd, st := a.shape[axis[k]], a.strides[axis[k]], a.strides[axis[k]+1]
+		v, wd, st := a.shape[axis[x]], a.strides[axis[x]], a.strides[axis[x]+1]
 		if st == 1 {
-			asm.Hadd(uint64(wd), a.data)
+			k.hadd(uint64(wd), a.data)
 			ln /= v
 			a.data = a.data[:ln]
 			continue
@@ -52,7 +52,7 @@ axisR:
 			t := a.data[w/wd*st : (w/wd+1)*st]
 			copy(t, a.data[w:w+st])
 			for i := 1; i*st+1 < wd; i++ {
-				asm.Vadd(t, a.data[w+(i)*st:w+(i+1)*st])
+				k.vadd(t, a.data[w+(i)*st:w+(i+1)*st])
 			}
 		}
 		ln /= v
@@ -76,23 +76,24 @@ axisR:
 // If all element values along the axis are NaN, NaN is in the return element.
 //
 // Empty call gives the grand sum of all elements.
-func (a *Array64) NaNSum(axis ...int) *Array64 {
-	if a.valAxis(&axis, "NaNSum") {
+func (a *Array[T]) NaNSum(axis ...int) *Array[T] {
+	k := kern[T]()
+	if a.valAxis(&axis, "NaNSum") || a.valOp(k.plus != nil, "NaNSum") {
 		return a
 	}
 
-	ns := func(d []float64) (r float64) {
+	ns := func(d []T) (r T) {
 		flag := false
 		for _, v := range d {
-			if !math.IsNaN(v) {
+			if v == v {
 				flag = true
-				r += v
+				r = k.plus(r, v)
 			}
 		}
 		if flag {
 			return r
 		}
-		return math.NaN()
+		return k.nan
 	}
 
 	return a.Fold(ns, axis...)
@@ -101,10 +102,10 @@ func (a *Array64) NaNSum(axis ...int) *Array64 {
 
 // Count gives the number of elements along a set of axis.
 // Value in the element is not tested, all elements are counted.
-func (a *Array64) Count(axis ...int) *Array64 {
+func (a *Array[T]) Count(axis ...int) *Array64 {
 	switch {
 	case a.valAxis(&axis, "Count"):
-		return a
+		return errTo[float64](a)
 	case len(axis) == 0:
 		return full(float64(a.strides[0]), 1)
 	}
@@ -127,7 +128,7 @@ cntAx:
 
 // count is an internal function for scalar count
 // TODO:  Make public?
-func (a *Array64) count(axis ...int) float64 {
+func (a *Array[T]) count(axis ...int) float64 {
 	if len(axis) == 0 {
 		return float64(a.strides[0])
 	}
@@ -142,53 +143,757 @@ func (a *Array64) count(axis ...int) float64 {
 
 // NaNCount calculates the number of values along a given axes.
 // Empty call gives the total number of elements.
-func (a *Array64) NaNCount(axis ...int) *Array64 {
+func (a *Array[T]) NaNCount(axis ...int) *Array64 {
 	if a.valAxis(&axis, "NaNCount") {
-		return a
+		return errTo[float64](a)
 	}
 
-	nc := func(d []float64) (r float64) {
-		for _, v := range d {
-			if !math.IsNaN(v) {
-				r++
-			}
+	r := newArray64(a.shape...)
+	for i, v := range a.flat() {
+		if v == v {
+			r.data[i] = 1
 		}
-		return r
 	}
+	return r.Sum(axis...)
+}
 
-	return a.Fold(nc, axis...)
+// nanCount calculates NaNCount with the element type of the array.
+// Axes must be validated before calling.
+func (a *Array[T]) nanCount(axis []int) *Array[T] {
+	one := kern[T]().fromFloat(1)
+	r := newArray[T](a.shape...)
+	for i, v := range a.flat() {
+		if v == v {
+			r.data[i] = one
+		}
+	}
+	return r.Sum(axis...)
 }
 
 // Mean calculates the mean across the given axes.
 // NaN values in the dataa will result in NaN result elements.
-func (a *Array64) Mean(axis ...int) *Array64 {
+// Integer arrays will truncate the mean towards zero.
+func (a *Array[T]) Mean(axis ...int) *Array[T] {
 	switch {
 	case a.valAxis(&axis, "Mean"):
 		return a
 	}
-	return a.C().Sum(axis...).DivC(a.count(axis...))
+	return a.C().Sum(axis...).DivC(kern[T]().fromFloat(a.count(axis...)))
 }
 
 // NaNMean calculates the mean across the given axes.
 // NaN values are ignored in this calculation.
-func (a *Array64) NaNMean(axis ...int) *Array64 {
+func (a *Array[T]) NaNMean(axis ...int) *Array[T] {
 	switch {
 	case a.valAxis(&axis, "Sum"):
 		return a
 	}
-	return a.NaNSum(axis...).Div(a.NaNCount(axis...))
+	return a.NaNSum(axis...).Div(a.nanCount(axis))
 }
 
 // Nonzero counts the number of non-zero elements in the array
-func (a *Array64) Nonzero(axis ...int) *Array64 {
+func (a *Array[T]) Nonzero(axis ...int) *Array64 {
 	if a.valAxis(&axis, "Nonzero") {
+		return errTo[float64](a)
+	}
+
+	var zero T
+	r := newArray64(a.shape...)
+	for i, v := range a.flat() {
+		if v != zero {
+			r.data[i] = 1
+		}
+	}
+	return r.Sum(axis...)
+}
+
+// Prod calculates the product of the elements along the given axes.
+// Empty call gives the product of all elements.
+func (a *Array[T]) Prod(axis ...int) *Array[T] {
+	k := kern[T]()
+	if a.valAxis(&axis, "Prod") || a.valOp(k.times != nil, "Prod") {
+		return a
+	}
+
+	one := k.fromFloat(1)
+	return a.Fold(func(d []T) T {
+		r := one
+		for _, v := range d {
+			r = k.times(r, v)
+		}
+		return r
+	}, axis...)
+}
+
+// NaNProd calculates the product of the elements along the given axes.
+// NaN values are ignored, so the product of all NaN values is one.
+func (a *Array[T]) NaNProd(axis ...int) *Array[T] {
+	k := kern[T]()
+	if a.valAxis(&axis, "NaNProd") || a.valOp(k.times != nil, "NaNProd") {
+		return a
+	}
+
+	one := k.fromFloat(1)
+	return a.Fold(func(d []T) T {
+		r := one
+		for _, v := range d {
+			if v == v {
+				r = k.times(r, v)
+			}
+		}
+		return r
+	}, axis...)
+}
+
+// reduce calculates f over the values of each span along axis, giving a float64 result.
+// Values are split into real and imaginary parts, and im is nil for arrays that aren't complex.
+// When nan is set, values with a NaN part are skipped.
+func (a *Array[T]) reduce(axis []int, nan bool, f func(re, im []float64) float64) *Array64 {
+	k := kern[T]()
+	d, sh, span := a.spans(axis)
+	r, re, im := newArray64(sh...), make([]float64, 0, span), []float64(nil)
+	if k.load(*new(T)).kind == 'c' {
+		im = make([]float64, 0, span)
+	}
+
+	for i := range r.data {
+		re = re[:0]
+		if im != nil {
+			im = im[:0]
+		}
+		for _, x := range d[i*span : (i+1)*span] {
+			s := k.load(x)
+			v, _ := s.float()
+			if nan && (v != v || s.kind == 'c' && imag(s.c) != imag(s.c)) {
+				continue
+			}
+			re = append(re, v)
+			if im != nil {
+				im = append(im, imag(s.c))
+			}
+		}
+		r.data[i] = f(re, im)
+	}
+	return r
+}
+
+// welford calculates the sum of squared deviations from the mean of v, with Welford's algorithm.
+func welford(v []float64) (m2 float64) {
+	var mean float64
+	for i, x := range v {
+		d := x - mean
+		mean += d / float64(i+1)
+		m2 += d * (x - mean)
+	}
+	return m2
+}
+
+// variance calculates the variance of the values, with ddof delta degrees of freedom.
+// Complex variance is the sum of the variances of the real and imaginary parts.
+func variance(ddof int) func(re, im []float64) float64 {
+	return func(re, im []float64) float64 {
+		m2, n := welford(re), float64(len(re)-ddof)
+		if im != nil {
+			m2 += welford(im)
+		}
+		if len(re) == 0 {
+			return math.NaN()
+		}
+		return m2 / math.Max(n, 0)
+	}
+}
+
+// Var calculates the variance of the elements along the given axes.
+// The divisor is N - ddof, where N is the number of elements, so ddof = 1 gives the sample variance.
+//
+// Empty call gives the variance of all elements.  Complex arrays give the variance of the
+// absolute deviations from the mean.  NaN values in the data will result in NaN result elements.
+func (a *Array[T]) Var(ddof int, axis ...int) *Array64 {
+	if a.valAxis(&axis, "Var") || a.valOp(kern[T]().sum != nil, "Var") {
+		return errTo[float64](a)
+	}
+	return a.reduce(axis, false, variance(ddof))
+}
+
+// NaNVar calculates the variance of the elements along the given axes, the same as Var.
+// NaN values are ignored in this calculation, and don't count towards N.
+func (a *Array[T]) NaNVar(ddof int, axis ...int) *Array64 {
+	if a.valAxis(&axis, "NaNVar") || a.valOp(kern[T]().sum != nil, "NaNVar") {
+		return errTo[float64](a)
+	}
+	return a.reduce(axis, true, variance(ddof))
+}
+
+// Std calculates the standard deviation of the elements along the given axes, which is the square root of Var.
+func (a *Array[T]) Std(ddof int, axis ...int) *Array64 {
+	if a.valAxis(&axis, "Std") || a.valOp(kern[T]().sum != nil, "Std") {
+		return errTo[float64](a)
+	}
+	return a.reduce(axis, false, variance(ddof)).Sqrt()
+}
+
+// NaNStd calculates the standard deviation of the elements along the given axes, ignoring NaN values.
+func (a *Array[T]) NaNStd(ddof int, axis ...int) *Array64 {
+	if a.valAxis(&axis, "NaNStd") || a.valOp(kern[T]().sum != nil, "NaNStd") {
+		return errTo[float64](a)
+	}
+	return a.reduce(axis, true, variance(ddof)).Sqrt()
+}
+
+// quantile calculates the q-th quantile of v, with linear interpolation between the closest values.
+// v is sorted in place.  NaN values in v give a NaN result.
+func quantile(v []float64, q float64) float64 {
+	if len(v) == 0 {
+		return math.NaN()
+	}
+
+	// NaN values are sorted to the front.
+	sort.Float64s(v)
+	if v[0] != v[0] {
+		return math.NaN()
+	}
+
+	p := q * float64(len(v)-1)
+	i := int(p)
+	if f := p - float64(i); f > 0 {
+		return v[i] + (v[i+1]-v[i])*f
+	}
+	return v[i]
+}
+
+// valQuantile checks the quantile and element type received by the quantile methods.
+func (a *Array[T]) valQuantile(q float64, axis *[]int, mthd string) bool {
+	switch {
+	case a.valAxis(axis, mthd):
+		return true
+	case a.valOp(kern[T]().isReal(), mthd):
+		return true
+	case !(q >= 0 && q <= 1):
+		a.err = IndexError
+		if debug {
+			a.debug = fmt.Sprintf("Quantile received by %s() is out of range: %v", mthd, q)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return true
+	}
+	return false
+}
+
+// Quantile calculates the q-th quantile of the elements along the given axes, where q is in the range [0, 1].
+// Values between two elements are interpolated linearly.
+//
+// Empty call gives the quantile of all elements.  NaN values in the data will result in NaN result elements.
+// Complex arrays are not supported.
+func (a *Array[T]) Quantile(q float64, axis ...int) *Array64 {
+	if a.valQuantile(q, &axis, "Quantile") {
+		return errTo[float64](a)
+	}
+	return a.reduce(axis, false, func(re, _ []float64) float64 { return quantile(re, q) })
+}
+
+// NaNQuantile calculates the q-th quantile of the elements along the given axes, the same as Quantile.
+// NaN values are ignored in this calculation.
+func (a *Array[T]) NaNQuantile(q float64, axis ...int) *Array64 {
+	if a.valQuantile(q, &axis, "NaNQuantile") {
+		return errTo[float64](a)
+	}
+	return a.reduce(axis, true, func(re, _ []float64) float64 { return quantile(re, q) })
+}
+
+// Percentile calculates the q-th percentile of the elements along the given axes, where q is in the range [0, 100].
+// This is the same as Quantile(q/100, axis...).
+func (a *Array[T]) Percentile(q float64, axis ...int) *Array64 {
+	if a.valQuantile(q/100, &axis, "Percentile") {
+		return errTo[float64](a)
+	}
+	return a.reduce(axis, false, func(re, _ []float64) float64 { return quantile(re, q/100) })
+}
+
+// NaNPercentile calculates the q-th percentile of the elements along the given axes, ignoring NaN values.
+func (a *Array[T]) NaNPercentile(q float64, axis ...int) *Array64 {
+	if a.valQuantile(q/100, &axis, "NaNPercentile") {
+		return errTo[float64](a)
+	}
+	return a.reduce(axis, true, func(re, _ []float64) float64 { return quantile(re, q/100) })
+}
+
+// Median calculates the median of the elements along the given axes.
+// This is the same as Quantile(0.5, axis...).
+func (a *Array[T]) Median(axis ...int) *Array64 {
+	if a.valQuantile(0.5, &axis, "Median") {
+		return errTo[float64](a)
+	}
+	return a.reduce(axis, false, func(re, _ []float64) float64 { return quantile(re, 0.5) })
+}
+
+// NaNMedian calculates the median of the elements along the given axes, ignoring NaN values.
+func (a *Array[T]) NaNMedian(axis ...int) *Array64 {
+	if a.valQuantile(0.5, &axis, "NaNMedian") {
+		return errTo[float64](a)
+	}
+	return a.reduce(axis, true, func(re, _ []float64) float64 { return quantile(re, 0.5) })
+}
+
+// Ptp calculates the range of the elements along the given axes, which is Max - Min.
+// Empty call gives the range of all elements.
+func (a *Array[T]) Ptp(axis ...int) *Array[T] {
+	if a.valAxis(&axis, "Ptp") || a.valOp(kern[T]().subtr != nil, "Ptp") {
 		return a
 	}
+	return a.Max(axis...).Subtr(a.Min(axis...))
+}
+
+// Average calculates the weighted average of the elements along the given axes.
+// A nil weights gives the same result as Mean.  Otherwise, weights must have the same shape as the array,
+// or be 1-D with the length of the single axis given, and the result is sum(a*weights) / sum(weights).
+//
+// Empty call gives the average of all elements.  Complex arrays are not supported.
+func (a *Array[T]) Average(weights *Array64, axis ...int) *Array64 {
+	switch {
+	case a.valAxis(&axis, "Average"), a.valOp(kern[T]().isReal(), "Average"):
+		return errTo[float64](a)
+	case weights == nil:
+		return a.AsFloat64().Mean(axis...)
+	case weights.HasErr():
+		a.err = weights.getErr()
+		if debug {
+			a.debug = "Weights received by Average() is in error."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	}
+
+	var w *Array64
+	switch {
+	case equalShape(a.shape, weights.shape):
+		w = weights.C()
+	case len(axis) == 1 && len(weights.shape) == 1 && weights.shape[0] == a.shape[axis[0]]:
+		sh := make([]int, len(a.shape))
+		for i := range sh {
+			sh[i] = 1
+		}
+		sh[axis[0]] = weights.shape[0]
+		w = full(1.0, a.shape...).Mult(weights.C().Reshape(sh...))
+	default:
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Weights received by Average() do not match.  Shape: %v  Weights shape: %v  Axes: %v", a.shape, weights.shape, axis)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	}
+	return a.AsFloat64().Mult(w).Sum(axis...).Div(w.Sum(axis...))
+}
+
+// moments calculates the second, third and fourth central moments of v.
+func moments(v []float64) (m2, m3, m4 float64) {
+	var mean float64
+	for _, x := range v {
+		mean += x
+	}
+	mean /= float64(len(v))
+	for _, x := range v {
+		d := x - mean
+		m2, m3, m4 = m2+d*d, m3+d*d*d, m4+d*d*d*d
+	}
+	n := float64(len(v))
+	return m2 / n, m3 / n, m4 / n
+}
+
+// Skew calculates the skewness of the elements along the given axes, m3 / m2^1.5,
+// where m2 and m3 are the second and third central moments.
+//
+// Empty call gives the skewness of all elements.  Constant values give NaN.
+// Complex arrays are not supported.
+func (a *Array[T]) Skew(axis ...int) *Array64 {
+	if a.valAxis(&axis, "Skew") || a.valOp(kern[T]().isReal(), "Skew") {
+		return errTo[float64](a)
+	}
+	return a.AsFloat64().Fold(func(d []float64) float64 {
+		m2, m3, _ := moments(d)
+		return m3 / math.Pow(m2, 1.5)
+	}, axis...)
+}
+
+// Kurtosis calculates the excess kurtosis of the elements along the given axes, m4 / m2^2 - 3,
+// where m2 and m4 are the second and fourth central moments.  Normally distributed values give zero.
+//
+// Empty call gives the kurtosis of all elements.  Constant values give NaN.
+// Complex arrays are not supported.
+func (a *Array[T]) Kurtosis(axis ...int) *Array64 {
+	if a.valAxis(&axis, "Kurtosis") || a.valOp(kern[T]().isReal(), "Kurtosis") {
+		return errTo[float64](a)
+	}
+	return a.AsFloat64().Fold(func(d []float64) float64 {
+		m2, _, m4 := moments(d)
+		return m4/(m2*m2) - 3
+	}, axis...)
+}
+
+// variables arranges a 1-D or 2-D array as a matrix with one variable in each row.
+// A 1-D array is a single variable, and rowvar selects whether the rows or columns of a 2-D array are variables.
+func (a *Array[T]) variables(rowvar bool) *Array64 {
+	switch {
+	case len(a.shape) == 1:
+		return a.AsFloat64().Reshape(1, a.shape[0])
+	case !rowvar:
+		return a.AsFloat64().permute([]int{1, 0})
+	}
+	return a.AsFloat64()
+}
+
+// Cov calculates the covariance matrix of the variables in the array.
+// When rowvar is set, each row of a 2-D array is a variable with observations in the columns,
+// otherwise each column is a variable.  A 1-D array is a single variable.
+// The variables of b are added after the variables of the array, and b can be nil.
+//
+// The divisor is N - ddof, where N is the number of observations, so ddof = 1 gives the sample covariance.
+// The result is a V×V matrix for V variables.  Complex arrays are not supported.
+func (a *Array[T]) Cov(b *Array[T], rowvar bool, ddof int) *Array64 {
+	switch {
+	case a.HasErr(), a.valOp(kern[T]().isReal(), "Cov"):
+		return errTo[float64](a)
+	case b != nil && b.HasErr():
+		a.err = b.getErr()
+		if debug {
+			a.debug = "Array received by Cov() is in error."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	case len(a.shape) > 2 || b != nil && len(b.shape) > 2:
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Arrays received by Cov() must be 1-D or 2-D.  Shape: %v", a.shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	}
+
+	x := a.variables(rowvar)
+	if b != nil {
+		y := b.variables(rowvar)
+		if y.shape[1] != x.shape[1] {
+			a.err = ShapeError
+			if debug {
+				a.debug = fmt.Sprintf("Observations received by Cov() do not match.  Shape: %v  Val shape: %v", a.shape, b.shape)
+				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return errTo[float64](a)
+		}
+		x.Append(y, 0)
+	}
+
+	v, n := x.shape[0], x.shape[1]
+	x.Subtr(x.Mean(1).Reshape(v, 1))
+	return x.MatProd(x.T()).DivC(math.Max(float64(n-ddof), 0))
+}
+
+// Corrcoef calculates the matrix of Pearson correlation coefficients of the variables in the array,
+// with the variables arranged the same as Cov.  Coefficients are clipped to the range [-1, 1], and
+// constant variables give NaN coefficients.  Complex arrays are not supported.
+func (a *Array[T]) Corrcoef(b *Array[T], rowvar bool) *Array64 {
+	c := a.Cov(b, rowvar, 0)
+	if c.HasErr() {
+		return c
+	}
+
+	v := c.shape[0]
+	s := make([]float64, v)
+	for i := range s {
+		s[i] = math.Sqrt(c.data[i*v+i])
+	}
+	for i, x := range c.data {
+		c.data[i] = x / (s[i/v] * s[i%v])
+	}
+	return c.Clip(-1, 1)
+}
+
+// NormOrd selects the norm calculated by Norm.
+// NormP creates vector norms of any order, and the matrix norms are listed below.
+type NormOrd struct {
+	matrix byte // 'n' for the nuclear norm, 's' for the spectral norm, 'f' for the Frobenius norm
+	p      float64
+}
+
+// NormP creates the vector p-norm order, the p-th root of the sum of |x|^p.
+// The order can be any value: +Inf gives the largest absolute value, -Inf gives the smallest,
+// and 0 counts the non-zero elements.
+//
+// Over two axes, orders 1, 2 and +Inf give the matrix norms of the same order, as in numpy:
+// the largest absolute column sum, the largest singular value and the largest absolute row sum.
+// Orders -1, -2 and -Inf give the smallest of each.
+func NormP(p float64) NormOrd {
+	return NormOrd{p: p}
+}
+
+// Norm orders
+var (
+	// NormL1 is the sum of absolute values.  Over two axes, it's the largest absolute column sum.
+	NormL1 = NormP(1)
+	// NormL2 is the Euclidean norm, the square root of the sum of squares.
+	// Over two axes, it's the spectral norm.
+	NormL2 = NormP(2)
+	// NormLinf is the largest absolute value.  Over two axes, it's the largest absolute row sum.
+	NormLinf = NormP(math.Inf(1))
+	// NormFro is the Frobenius norm of a matrix, which is the Euclidean norm of all of its elements.
+	NormFro = NormOrd{matrix: 'f'}
+	// NormNuc is the nuclear norm of a matrix, the sum of its singular values.
+	NormNuc = NormOrd{matrix: 'n'}
+	// NormSpectral is the spectral norm of a matrix, its largest singular value.
+	NormSpectral = NormOrd{matrix: 's'}
+)
+
+// Norm calculates the norm of the elements along the given axes.
+// Empty call gives the norm of all elements.
+//
+// Vector norms treat the elements on all of the axes as one vector, the same way Sum does.
+// Over exactly two axes, or a 2-D array with an empty call, the orders of NormP that have a
+// matrix norm calculate the matrix norm instead, with the first axis as the rows of the matrix.
+// NormFro, NormNuc and NormSpectral always need two axes.  Complex values use their absolute values,
+// and values are scaled by the largest absolute value to avoid overflow.
+func (a *Array[T]) Norm(ord NormOrd, axis ...int) *Array64 {
+	k := kern[T]()
+	if a.valAxis(&axis, "Norm") || a.valOp(k.sum != nil, "Norm") {
+		return errTo[float64](a)
+	}
+	if len(axis) == 0 {
+		for i := range a.shape {
+			axis = append(axis, i)
+		}
+	}
+	if ord.matrix != 0 && len(axis) != 2 {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Matrix norm received by Norm() needs two axes.  Shape: %v  Axes: %v", a.shape, axis)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return errTo[float64](a)
+	}
+
+	kind := ord.matrix
+	if kind == 0 && len(axis) == 2 {
+		kind = ord.matKind()
+	}
+
+	d, sh, span := a.spans(axis)
+	r := newArray64(sh...)
+	v, cplx := make([]scalar, span), k.load(*new(T)).kind == 'c'
+	for i := range r.data {
+		for j, x := range d[i*span : (i+1)*span] {
+			v[j] = k.load(x)
+		}
+		if kind == 0 {
+			r.data[i] = pNorm(v, ord.p)
+			continue
+		}
+
+		var ok bool
+		if r.data[i], ok = matNorm(v, a.shape[axis[0]], a.shape[axis[1]], kind, ord.p < 0, cplx); !ok {
+			a.err = NoConvergence
+			if debug {
+				a.debug = fmt.Sprintf("Singular values did not converge in Norm().  Shape: %v  Axes: %v", a.shape, axis)
+				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return errTo[float64](a)
+		}
+	}
+	return r
+}
+
+// spans gathers the elements along the given axes into contiguous spans, with one span for each element
+// of the reduced shape sh.  An empty axis list gathers all elements into one span.
+// Axes must be validated before calling.
+func (a *Array[T]) spans(axis []int) (d []T, sh []int, span int) {
+	if len(axis) == 0 {
+		return a.flat(), []int{1}, a.strides[0]
+	}
+
+	perm, span := make([]int, 0, len(a.shape)), 1
+	for i := range a.shape {
+		if !containsInt(axis, i) {
+			perm, sh = append(perm, i), append(sh, a.shape[i])
+		}
+	}
+	for _, v := range axis {
+		span *= a.shape[v]
+	}
+	if len(sh) == 0 {
+		sh = append(sh, 1)
+	}
+	return a.permute(append(perm, axis...)).data, sh, span
+}
+
+// containsInt reports whether v is in s.
+func containsInt(s []int, v int) bool {
+	for _, w := range s {
+		if w == v {
+			return true
+		}
+	}
+	return false
+}
+
+// abs returns the absolute value of a scalar.
+func (s scalar) abs() float64 {
+	switch s.kind {
+	case 'i':
+		return math.Abs(float64(s.i))
+	case 'u':
+		return float64(s.u)
+	case 'c':
+		return math.Hypot(real(s.c), imag(s.c))
+	}
+	return math.Abs(s.f)
+}
 
-	return a.Map(func(d float64) float64 {
-		if d == 0 {
-			return 0
+// pNorm calculates the p-norm of a vector, scaled by the largest absolute value.
+func pNorm(v []scalar, p float64) (n float64) {
+	mx, mn, nz := 0.0, math.Inf(1), 0.0
+	for _, s := range v {
+		x := s.abs()
+		if x != x {
+			return math.NaN()
 		}
-		return 1
-	}).Sum(axis...)
+		mx, mn = math.Max(mx, x), math.Min(mn, x)
+		if x != 0 {
+			nz++
+		}
+	}
+
+	switch {
+	case p == 0:
+		return nz
+	case math.IsInf(p, 1):
+		return mx
+	case math.IsInf(p, -1):
+		return mn
+	case mx == 0 || math.IsInf(mx, 1):
+		// Scaling by zero or infinity isn't possible, and isn't needed.
+		mx = 1
+	}
+
+	for _, s := range v {
+		switch x := s.abs() / mx; p {
+		case 1:
+			n += x
+		case 2:
+			n += x * x
+		default:
+			n += math.Pow(x, p)
+		}
+	}
+
+	switch p {
+	case 1:
+		return mx * n
+	case 2:
+		return mx * math.Sqrt(n)
+	}
+	return mx * math.Pow(n, 1/p)
+}
+
+// matKind selects the matrix norm of a vector order, or 0 when the order has no matrix norm.
+// 'c' is the column sum norm and 'r' is the row sum norm.
+func (o NormOrd) matKind() byte {
+	switch {
+	case o.p == 1, o.p == -1:
+		return 'c'
+	case o.p == 2, o.p == -2:
+		return 's'
+	case math.IsInf(o.p, 0):
+		return 'r'
+	}
+	return 0
+}
+
+// matNorm calculates the matrix norm of an m×n matrix.  low selects the smallest column sum,
+// row sum or singular value, instead of the largest.
+// Singular values are calculated scaled by the largest absolute value.  Complex matrices are embedded
+// in a real 2m×2n matrix, which has each singular value twice.
+func matNorm(v []scalar, m, n int, kind byte, low, cplx bool) (float64, bool) {
+	switch kind {
+	case 'f':
+		return pNorm(v, 2), true
+	case 'c', 'r':
+		return sumNorm(v, m, n, kind == 'c', low), true
+	}
+
+	var mx float64
+	for _, s := range v {
+		if x := s.abs(); x != x || math.IsInf(x, 1) {
+			return x, true
+		} else if x > mx {
+			mx = x
+		}
+	}
+	if mx == 0 {
+		return 0, true
+	}
+
+	d := make([]float64, m*n)
+	if cplx {
+		d = make([]float64, 4*m*n)
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				c := v[i*n+j].c / complex(mx, 0)
+				d[i*2*n+j], d[i*2*n+n+j] = real(c), -imag(c)
+				d[(m+i)*2*n+j], d[(m+i)*2*n+n+j] = imag(c), real(c)
+			}
+		}
+		m, n = 2*m, 2*n
+	} else {
+		for i, s := range v {
+			d[i], _ = s.float()
+			d[i] /= mx
+		}
+	}
+
+	_, sv, _, ok := mat.SVD(d, m, n)
+	if !ok || len(sv) == 0 {
+		return 0, ok
+	}
+	if kind == 's' && low {
+		return mx * sv[len(sv)-1], true
+	}
+	if kind == 's' {
+		return mx * sv[0], true
+	}
+
+	var sum float64
+	for _, x := range sv {
+		sum += x
+	}
+	if cplx {
+		sum /= 2
+	}
+	return mx * sum, true
+}
+
+// sumNorm calculates the largest absolute column sum of an m×n matrix, or the largest absolute
+// row sum when col is false.  low selects the smallest sum instead.
+func sumNorm(v []scalar, m, n int, col, low bool) float64 {
+	sums := make([]float64, m)
+	if col {
+		sums = make([]float64, n)
+	}
+	if len(sums) == 0 {
+		return 0
+	}
+	for i, s := range v {
+		if col {
+			sums[i%n] += s.abs()
+		} else {
+			sums[i/n] += s.abs()
+		}
+	}
+
+	r := sums[0]
+	for _, x := range sums[1:] {
+		if low {
+			r = math.Min(r, x)
+		} else {
+			r = math.Max(r, x)
+		}
+	}
+	return r
 }
@@ -356,3 +356,359 @@ func BenchmarkC(t *testing.B) {
 	t.StopTimer()
 	runtime.GC()
 }
+
+func TestNorm(t *testing.T) {
+	t.Parallel()
+	v := NewArray64([]float64{3, -4, 0, 12}, 4)
+	m := NewArray64([]float64{1, -2, 3, -4, 5, -6}, 2, 3)
+	for i, tst := range []struct {
+		a   *Array64
+		exp []float64
+	}{
+		{v.Norm(NormL1), []float64{19}},
+		{v.Norm(NormL2), []float64{13}},
+		{v.Norm(NormLinf), []float64{12}},
+		{v.Norm(NormP(math.Inf(-1))), []float64{0}},
+		{v.Norm(NormP(0)), []float64{3}},
+		{v.Norm(NormP(3)), []float64{math.Cbrt(27 + 64 + 1728)}},
+		{NewArray64([]float64{1e200, -1e200}, 2).Norm(NormL2), []float64{1e200 * math.Sqrt2}},
+		{NewArray64([]float64{1e-200, 1e-200}, 2).Norm(NormL2), []float64{1e-200 * math.Sqrt2}},
+		{NewArray64([]float64{1, math.NaN()}, 2).Norm(NormLinf), []float64{math.NaN()}},
+		{NewArray64([]float64{1, math.Inf(-1)}, 2).Norm(NormL2), []float64{math.Inf(1)}},
+		{NewArray64(nil, 3).Norm(NormL2), []float64{0}},
+		{NewArray64([]float64{3, 4, 0, 1, 0, 0}, 2, 3).Norm(NormL2, 1), []float64{5, 1}},
+		{NewArray64([]float64{3, 4, 0, 1, 0, 0}, 2, 3).Norm(NormL1, 0), []float64{4, 4, 0}},
+		{Arange(24).Reshape(2, 3, 4).Norm(NormFro, 0, 2), []float64{math.Sqrt(748), math.Sqrt(1356), math.Sqrt(2220)}},
+		{NewArray64([]float64{3, 0, 0, -2}, 2, 2).Norm(NormNuc), []float64{5}},
+		{NewArray64([]float64{3, 0, 0, -2}, 2, 2).Norm(NormSpectral), []float64{3}},
+		{NewArray64([]float64{3, 0, 0, -2}, 2, 2).Norm(NormFro), []float64{math.Sqrt(13)}},
+		{NewArray64([]float64{3, 2, 2, 2, 3, -2}, 2, 3).Norm(NormNuc, 1, 0), []float64{8}},
+		{NewArray64([]float64{3e300, 2e300, 2e300, 2e300, 3e300, -2e300}, 2, 3).Norm(NormSpectral), []float64{5e300}},
+		{NewArray64([]float64{3, 0, 0, -2, 1, 0, 0, 0}, 2, 2, 2).Norm(NormNuc, 1, 2), []float64{5, 1}},
+		{NewArray[complex128]([]complex128{1i, 0, 0, 2}, 2, 2).Norm(NormNuc), []float64{3}},
+		{NewArray[complex128]([]complex128{1i, 0, 0, 2}, 2, 2).Norm(NormSpectral), []float64{2}},
+		{NewArray[complex128]([]complex128{3 + 4i, 0}, 2).Norm(NormL1), []float64{5}},
+		{m.Norm(NormL1), []float64{9}},
+		{m.Norm(NormP(-1)), []float64{5}},
+		{m.Norm(NormLinf), []float64{15}},
+		{m.Norm(NormP(math.Inf(-1))), []float64{6}},
+		{m.Norm(NormL2), []float64{math.Sqrt((91 + math.Sqrt(8065)) / 2)}},
+		{m.Norm(NormP(-2)), []float64{math.Sqrt((91 - math.Sqrt(8065)) / 2)}},
+		{m.Norm(NormFro), []float64{math.Sqrt(91)}},
+		{m.Norm(NormP(3)), []float64{math.Cbrt(1 + 8 + 27 + 64 + 125 + 216)}},
+		{Arange(24).Reshape(2, 3, 4).Norm(NormL1, 1, 2), []float64{21, 57}},
+		{Arange(24).Reshape(2, 3, 4).Norm(NormLinf, 2, 1), []float64{21, 57}},
+		{Arange(24).Reshape(2, 3, 4).Norm(NormL1), []float64{276}},
+		{NewArray[complex128]([]complex128{3 + 4i, 0, 0, 1}, 2, 2).Norm(NormL1), []float64{5}},
+		{NewArray64(nil, 0, 3).Norm(NormLinf), []float64{0}},
+		{NewArray[int8]([]int8{-128, 0, 0}, 3).Norm(NormL2), []float64{128}},
+		{NewArray[uint32]([]uint32{1, 2, 2}, 3).Norm(NormL2), []float64{3}},
+	} {
+		if e := tst.a.GetErr(); e != nil {
+			t.Log("Test", i, "Unexpected error", e)
+			t.Fail()
+			continue
+		}
+		if len(tst.a.data) != len(tst.exp) {
+			t.Log("Test", i, "Expected", tst.exp, "Received", tst.a)
+			t.Fail()
+			continue
+		}
+		for j, v := range tst.a.data {
+			if x := tst.exp[j]; !(v == x || math.Abs(v-x) <= 1e-12*math.Abs(x) || x != x && v != v) {
+				t.Log("Test", i, "Expected", tst.exp, "Received", tst.a)
+				t.Fail()
+				break
+			}
+		}
+	}
+
+	for i, tst := range []struct {
+		a   *Array64
+		err error
+	}{
+		{NewArrayB([]bool{true, false}, 2).Norm(NormL1), TypeError},
+		{NewArray64(nil, 4).Norm(NormNuc), ShapeError},
+		{NewArray64(nil, 4).Norm(NormFro), ShapeError},
+		{Arange(24).Reshape(2, 3, 4).Norm(NormSpectral), ShapeError},
+		{NewArray64(nil, 4).Norm(NormL2, 1), IndexError},
+		{NewArray64(nil, 4).Reshape(3).Norm(NormL2), ReshapeError},
+	} {
+		if e := tst.a.GetErr(); e != tst.err {
+			t.Log("Test", i, "Expected", tst.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
+
+func TestProd(t *testing.T) {
+	t.Parallel()
+	nan := math.NaN()
+	a := Arange(1, 6).Reshape(2, 3)
+	for i, v := range []struct {
+		a, res *Array64
+	}{
+		{a.C().Prod(), NewArray64([]float64{720})},
+		{a.C().Prod(0), NewArray64([]float64{4, 10, 18})},
+		{a.C().Prod(1), NewArray64([]float64{6, 120})},
+		{a.C().Prod(1, 0), NewArray64([]float64{720})},
+		{NewArray64([]float64{2, nan, 3}).Prod(), NewArray64([]float64{nan})},
+		{NewArray64([]float64{2, nan, 3}).NaNProd(), NewArray64([]float64{6})},
+		{NewArray64([]float64{nan, nan, 3, nan}, 2, 2).NaNProd(1), NewArray64([]float64{1, 3})},
+	} {
+		if !sameNaN(v.a, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Received", v.a)
+			t.Fail()
+		}
+	}
+
+	if r := NewArray[int32]([]int32{-2, 3, 4}, 3).Prod(); r.At(0) != -24 {
+		t.Log("Int Prod failed", r)
+		t.Fail()
+	}
+	if e := NewArrayB([]bool{true}, 1).Prod().GetErr(); e != TypeError {
+		t.Log("Expected TypeError Received", e)
+		t.Fail()
+	}
+	if e := a.C().Prod(2).GetErr(); e != IndexError {
+		t.Log("Expected IndexError Received", e)
+		t.Fail()
+	}
+}
+
+func TestVar(t *testing.T) {
+	t.Parallel()
+	nan := math.NaN()
+	a := NewArray64([]float64{2, 4, 4, 4, 5, 5, 7, 9}, 2, 4)
+	for i, v := range []struct {
+		a, res *Array64
+	}{
+		{a.Var(0), NewArray64([]float64{4})},
+		{a.Var(1), NewArray64([]float64{32.0 / 7})},
+		{a.Std(0), NewArray64([]float64{2})},
+		{a.Var(0, 1), NewArray64([]float64{0.75, 2.75})},
+		{a.Var(0, 0), NewArray64([]float64{2.25, 0.25, 2.25, 6.25})},
+		{a.Std(1, 0), NewArray64([]float64{math.Sqrt(4.5), math.Sqrt(0.5), math.Sqrt(4.5), math.Sqrt(12.5)})},
+		{a.Var(0, 0, 1), NewArray64([]float64{4})},
+		{NewArray64([]float64{3}).Var(1), NewArray64([]float64{nan})},
+		{NewArray64([]float64{3, 5}).Var(2), NewArray64([]float64{math.Inf(1)})},
+		{NewArray64([]float64{1, nan}).Var(0), NewArray64([]float64{nan})},
+		{NewArray64([]float64{1, nan, 3}).NaNVar(0), NewArray64([]float64{1})},
+		{NewArray64([]float64{1, nan, 3, nan}, 2, 2).NaNStd(0, 0), NewArray64([]float64{1, nan})},
+		{NewArray64([]float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}).Var(0), NewArray64([]float64{22.5})},
+	} {
+		if !sameNaN(v.a, v.res) && !v.a.C().Subtr(v.res).Abs().Less(full(1e-12, v.res.shape...)).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Received", v.a)
+			t.Fail()
+		}
+	}
+
+	if r := NewArray[int8]([]int8{1, 3}, 2).Var(0); r.At(0) != 1 {
+		t.Log("Int Var failed", r)
+		t.Fail()
+	}
+	if r := NewArray[complex128]([]complex128{1 + 1i, -1 - 1i}, 2).Var(0); r.At(0) != 2 {
+		t.Log("Complex Var failed", r)
+		t.Fail()
+	}
+	if e := NewArrayB([]bool{true}, 1).Std(0).GetErr(); e != TypeError {
+		t.Log("Expected TypeError Received", e)
+		t.Fail()
+	}
+	if e := a.C().Reshape(3).Var(0).GetErr(); e != ReshapeError {
+		t.Log("Expected ReshapeError Received", e)
+		t.Fail()
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	t.Parallel()
+	nan := math.NaN()
+	a := NewArray64([]float64{7, 1, 3, 4, 10, 2, 8, 6, 9, 5, 0, 11}, 3, 4)
+	for i, v := range []struct {
+		a, res *Array64
+	}{
+		{a.Median(), NewArray64([]float64{5.5})},
+		{a.Median(1), NewArray64([]float64{3.5, 7, 7})},
+		{a.Median(0), NewArray64([]float64{9, 2, 3, 6})},
+		{a.Quantile(0, 1), NewArray64([]float64{1, 2, 0})},
+		{a.Quantile(1, 1), NewArray64([]float64{7, 10, 11})},
+		{a.Quantile(0.25), NewArray64([]float64{2.75})},
+		{a.Percentile(25), NewArray64([]float64{2.75})},
+		{a.Percentile(90, 0, 1), NewArray64([]float64{9.9})},
+		{NewArray64([]float64{1, nan, 3}).Median(), NewArray64([]float64{nan})},
+		{NewArray64([]float64{1, nan, 3}).NaNMedian(), NewArray64([]float64{2})},
+		{NewArray64([]float64{1, nan, nan, nan}, 2, 2).NaNQuantile(0.5, 1), NewArray64([]float64{1, nan})},
+		{NewArray64([]float64{4, nan, 1, 2}).NaNPercentile(50), NewArray64([]float64{2})},
+	} {
+		if !sameNaN(v.a, v.res) && !v.a.C().Subtr(v.res).Abs().Less(full(1e-12, v.res.shape...)).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Received", v.a)
+			t.Fail()
+		}
+	}
+
+	if r := NewArray[uint16]([]uint16{4, 1, 2, 3}, 4).Median(); r.At(0) != 2.5 {
+		t.Log("Int Median failed", r)
+		t.Fail()
+	}
+	if !a.Equals(NewArray64([]float64{7, 1, 3, 4, 10, 2, 8, 6, 9, 5, 0, 11}, 3, 4)).All().At(0) {
+		t.Log("Source array modified", a)
+		t.Fail()
+	}
+
+	for i, v := range []struct {
+		a   *Array64
+		err error
+	}{
+		{a.C().Quantile(1.5), IndexError},
+		{a.C().Quantile(nan), IndexError},
+		{a.C().Percentile(-1), IndexError},
+		{a.C().Median(3), IndexError},
+		{NewArray[complex64]([]complex64{1}, 1).Median(), TypeError},
+		{NewArrayB([]bool{true}, 1).Percentile(50), TypeError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
+
+func TestCov(t *testing.T) {
+	t.Parallel()
+	x := NewArray64([]float64{0, 1, 2, 2, 1, 0}, 2, 3)
+	a, b := NewArray64([]float64{1, 2, 3, 4}), NewArray64([]float64{2, 4, 6, 9})
+	for i, v := range []struct {
+		a, res *Array64
+	}{
+		{x.Cov(nil, true, 1), NewArray64([]float64{1, -1, -1, 1}, 2, 2)},
+		{x.C().T().Cov(nil, false, 1), NewArray64([]float64{1, -1, -1, 1}, 2, 2)},
+		{x.Cov(nil, false, 0), NewArray64([]float64{1, 0, -1, 0, 0, 0, -1, 0, 1}, 3, 3)},
+		{a.Cov(nil, true, 0), NewArray64([]float64{1.25}, 1, 1)},
+		{a.Cov(b, true, 1), NewArray64([]float64{5.0 / 3, 11.5 / 3, 11.5 / 3, 26.75 / 3}, 2, 2)},
+		{NewArray[int16]([]int16{1, 2, 3, 4}, 4).Cov(nil, true, 4), NewArray64([]float64{math.Inf(1)}, 1, 1)},
+		{x.Corrcoef(nil, true), NewArray64([]float64{1, -1, -1, 1}, 2, 2)},
+		{a.Corrcoef(b, true), NewArray64([]float64{1, 11.5 / math.Sqrt(5*26.75), 11.5 / math.Sqrt(5*26.75), 1}, 2, 2)},
+		{NewArray64([]float64{1, 1, 1, 1, 2, 3}, 2, 3).Corrcoef(nil, true), NewArray64([]float64{math.NaN(), math.NaN(), math.NaN(), 1}, 2, 2)},
+	} {
+		if e := v.a.GetErr(); e != nil {
+			t.Log("Test", i, "Unexpected error", e)
+			t.Fail()
+			continue
+		}
+		if !equalShape(v.a.shape, v.res.shape) || !v.a.C().Subtr(v.res).Abs().Max().Less(full(1e-12, 1)).At(0) && !sameNaN(v.a, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Received", v.a)
+			t.Fail()
+		}
+	}
+
+	if !x.Equals(NewArray64([]float64{0, 1, 2, 2, 1, 0}, 2, 3)).All().At(0) {
+		t.Log("Cov modified the source array", x)
+		t.Fail()
+	}
+
+	for i, v := range []struct {
+		a   *Array64
+		err error
+	}{
+		{Arange(8).Reshape(2, 2, 2).Cov(nil, true, 1), ShapeError},
+		{x.C().Cov(Arange(8).Reshape(2, 2, 2), true, 1), ShapeError},
+		{x.C().Cov(Arange(4), true, 1), ShapeError},
+		{x.C().Cov(Arange(4).Reshape(3), true, 1), ReshapeError},
+		{NewArray[complex64]([]complex64{1, 2}, 2).Cov(nil, true, 0), TypeError},
+		{NewArrayB([]bool{true, false}, 2).Corrcoef(nil, true), TypeError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
+
+func TestAverage(t *testing.T) {
+	t.Parallel()
+	a := Arange(6).Reshape(2, 3)
+	for i, v := range []struct {
+		a, res *Array64
+	}{
+		{a.Average(nil), NewArray64([]float64{2.5})},
+		{a.Average(nil, 0), NewArray64([]float64{1.5, 2.5, 3.5})},
+		{a.Average(NewArray64([]float64{1, 2, 3}), 1), NewArray64([]float64{8.0 / 6, 26.0 / 6})},
+		{a.Average(NewArray64([]float64{1, 3}), 0), NewArray64([]float64{2.25, 3.25, 4.25})},
+		{a.Average(NewArray64([]float64{1, 1, 1, 0, 0, 2}, 2, 3)), NewArray64([]float64{2.6})},
+		{a.Average(NewArray64([]float64{1, 1, 1, 0, 0, 2}, 2, 3), 0), NewArray64([]float64{0, 1, 4})},
+		{NewArray[uint8]([]uint8{1, 2}, 2).Average(nil), NewArray64([]float64{1.5})},
+	} {
+		if e := v.a.GetErr(); e != nil || !sameNaN(v.a, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Received", v.a, e)
+			t.Fail()
+		}
+	}
+
+	for i, v := range []struct {
+		a   *Array64
+		err error
+	}{
+		{a.C().Average(NewArray64([]float64{1, 2})), ShapeError},
+		{a.C().Average(NewArray64([]float64{1, 2}), 1), ShapeError},
+		{a.C().Average(NewArray64([]float64{1, 2, 3}), 0, 1), ShapeError},
+		{a.C().Average(nil, 2), IndexError},
+		{a.C().Average(Arange(4).Reshape(3)), ReshapeError},
+		{NewArray[complex128]([]complex128{1}, 1).Average(nil), TypeError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
+
+func TestMoments(t *testing.T) {
+	t.Parallel()
+	a := Arange(6).Reshape(2, 3)
+	for i, v := range []struct {
+		a, res *Array64
+	}{
+		{a.Ptp(), NewArray64([]float64{5})},
+		{a.Ptp(0), NewArray64([]float64{3, 3, 3})},
+		{a.Ptp(1), NewArray64([]float64{2, 2})},
+		{NewArray64([]float64{1, 2, 3}).Skew(), NewArray64([]float64{0})},
+		{NewArray64([]float64{0, 0, 0, 1}).Skew(), NewArray64([]float64{2 / math.Sqrt(3)})},
+		{NewArray64([]float64{0, 0, 1, 0, 0, 0, 0, 1}, 4, 2).Skew(0), NewArray64([]float64{2 / math.Sqrt(3), 2 / math.Sqrt(3)})},
+		{NewArray64([]float64{0, 0, 0, 1}).Kurtosis(), NewArray64([]float64{-2.0 / 3})},
+		{NewArray64([]float64{1, 2, 1, 2}, 2, 2).Kurtosis(1), NewArray64([]float64{-2, -2})},
+		{NewArray64([]float64{4, 4}).Skew(), NewArray64([]float64{math.NaN()})},
+	} {
+		if e := v.a.GetErr(); e != nil {
+			t.Log("Test", i, "Unexpected error", e)
+			t.Fail()
+			continue
+		}
+		if !equalShape(v.a.shape, v.res.shape) || !v.a.C().Subtr(v.res).Abs().Max().Less(full(1e-12, 1)).At(0) && !sameNaN(v.a, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Received", v.a)
+			t.Fail()
+		}
+	}
+
+	if p := NewArray[int8]([]int8{5, -2, 9}, 3).Ptp(); p.At(0) != 11 {
+		t.Log("Int Ptp failed", p)
+		t.Fail()
+	}
+
+	for i, v := range []struct {
+		a   interface{ GetErr() error }
+		err error
+	}{
+		{NewArrayB([]bool{true}, 1).Ptp(), TypeError},
+		{a.C().Ptp(2), IndexError},
+		{a.C().Skew(-1), IndexError},
+		{NewArray[complex128]([]complex128{1}, 1).Skew(), TypeError},
+		{NewArray[complex128]([]complex128{1}, 1).Kurtosis(), TypeError},
+		{Arange(4).Reshape(3).Kurtosis(), ReshapeError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
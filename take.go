@@ -0,0 +1,237 @@
+package numgo
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+)
+
+// Take selects the elements at the given indices along an axis.
+// The result has the shape of the array, with the length of the axis replaced by the number of indices.
+// Negative indices count back from the end of the axis.  A new array is returned.
+//
+// Out of range indices will generate an IndexError.
+func (a *Array[T]) Take(indices []int, axis int) *Array[T] {
+	if a.valAxes([]int{axis}, "Take") {
+		return a
+	}
+
+	sh := append([]int(nil), a.shape...)
+	sh[axis] = len(indices)
+	ist := make([]int, len(sh))
+	ist[axis] = 1
+
+	pos, ok := a.takeOffsets(indices, ist, sh, axis, "Take")
+	if !ok {
+		return a
+	}
+	r := newArray[T](sh...)
+	for i, p := range pos {
+		r.data[i] = a.data[p]
+	}
+	return r
+}
+
+// Put sets the elements at the given flat indices, in row-major order, to the values in vals.
+// The values in vals are repeated if there are more indices than values.
+// Negative indices count back from the end of the array.
+//
+// Source Array is returned, for function-chaining design.
+func (a *Array[T]) Put(indices []int, vals *Array[T]) *Array[T] {
+	switch {
+	case a.HasErr():
+		return a
+	case vals == nil:
+		a.err = NilError
+		if debug {
+			a.debug = "Array received by Put() is a Nil pointer."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	case vals.HasErr():
+		a.err = vals.getErr()
+		if debug {
+			a.debug = "Array received by Put() is in error."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	case len(indices) > 0 && vals.strides[0] == 0:
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Empty array received by Put().  Indices: %v", indices)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	}
+
+	idx := make([]int, len(indices))
+	for i, v := range indices {
+		if v < 0 {
+			v += a.strides[0]
+		}
+		if v < 0 || v >= a.strides[0] {
+			a.err = IndexError
+			if debug {
+				a.debug = fmt.Sprintf("Index received by Put() does not exist size: %d index: %v", a.strides[0], indices)
+				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return a
+		}
+		idx[i] = v
+	}
+
+	vd := vals.valCopy(vals.shape)
+	a.apply(func(d []T) {
+		for i, v := range idx {
+			d[v] = vd[i%len(vd)]
+		}
+	})
+	return a
+}
+
+// TakeAlongAxis selects elements along an axis using an array of indices, such as the result of an argsort.
+// idx must have the same number of axes as the array, holding integer values.  The other axes are
+// broadcast together, and the result has the length of idx along the axis.  A new array is returned.
+//
+// Out of range indices will generate an IndexError, and non-integer indices an InvIndexError.
+func (a *Array[T]) TakeAlongAxis(idx *Array64, axis int) *Array[T] {
+	ind, ist, sh, ok := a.valAlongAxis(idx, axis, "TakeAlongAxis")
+	if !ok {
+		return a
+	}
+	pos, ok := a.takeOffsets(ind, ist, sh, axis, "TakeAlongAxis")
+	if !ok {
+		return a
+	}
+
+	r := newArray[T](sh...)
+	for i, p := range pos {
+		r.data[i] = a.data[p]
+	}
+	return r
+}
+
+// PutAlongAxis sets the elements selected by TakeAlongAxis to the values in vals.
+// vals must be able to broadcast to the shape of the selection.
+//
+// Source Array is returned, for function-chaining design.
+func (a *Array[T]) PutAlongAxis(idx *Array64, vals *Array[T], axis int) *Array[T] {
+	switch {
+	case a.HasErr():
+		return a
+	case vals == nil:
+		a.err = NilError
+		if debug {
+			a.debug = "Array received by PutAlongAxis() is a Nil pointer."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	case vals.HasErr():
+		a.err = vals.getErr()
+		if debug {
+			a.debug = "Array received by PutAlongAxis() is in error."
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	}
+
+	ind, ist, sh, ok := a.valAlongAxis(idx, axis, "PutAlongAxis")
+	if !ok {
+		return a
+	}
+	if b, ok := broadcastShape(sh, vals.shape); !ok || !equalShape(b, sh) {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Array received by PutAlongAxis() can not be broadcast.  Selection shape: %v  Vals shape: %v", sh, vals.shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return a
+	}
+	pos, ok := a.takeOffsets(ind, ist, sh, axis, "PutAlongAxis")
+	if !ok {
+		return a
+	}
+
+	vd := vals.valCopy(sh)
+	for i, p := range pos {
+		a.data[p] = vd[i]
+	}
+	return a
+}
+
+// valAlongAxis validates the index array for TakeAlongAxis and PutAlongAxis.
+// The integer indices are returned along with their strides in, and the shape of, the selection.
+func (a *Array[T]) valAlongAxis(idx *Array64, axis int, mthd string) (ind, ist, sh []int, ok bool) {
+	switch {
+	case a.valAxes([]int{axis}, mthd):
+		return nil, nil, nil, false
+	case idx == nil:
+		a.err = NilError
+		if debug {
+			a.debug = fmt.Sprintf("Index array received by %s() is a Nil pointer.", mthd)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return nil, nil, nil, false
+	case idx.HasErr():
+		a.err = idx.getErr()
+		if debug {
+			a.debug = fmt.Sprintf("Index array received by %s() is in error.", mthd)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return nil, nil, nil, false
+	}
+
+	// The axis is excluded from broadcasting by setting its length to 1 in the array shape.
+	ash := append([]int(nil), a.shape...)
+	ash[axis] = 1
+	sh, ok = broadcastShape(ash, idx.shape)
+	if !ok || len(idx.shape) != len(a.shape) {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Index array received by %s() can not be broadcast.  Shape: %v  Index shape: %v", mthd, a.shape, idx.shape)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return nil, nil, nil, false
+	}
+
+	d := idx.flat()
+	ind = make([]int, len(d))
+	for i, v := range d {
+		if v != math.Trunc(v) || math.IsInf(v, 0) {
+			a.err = InvIndexError
+			if debug {
+				a.debug = fmt.Sprintf("Non-integer index received by %s().  Value: %v", mthd, v)
+				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return nil, nil, nil, false
+		}
+		ind[i] = int(v)
+	}
+	return ind, broadcastStrides(sh, idx.shape, rowStrides(idx.shape)), sh, true
+}
+
+// takeOffsets calculates the data position of each element selected by indices along an axis.
+// ist holds the strides of the indices in the selection shape sh.
+func (a *Array[T]) takeOffsets(indices, ist, sh []int, axis int, mthd string) ([]int, bool) {
+	ast := broadcastStrides(sh, a.shape, a.strides[1:])
+	ast[axis] = 0
+
+	ln, step := a.shape[axis], a.strides[axis+1]
+	pos, ip := offsets(a.offset, sh, ast), offsets(0, sh, ist)
+	for i := range pos {
+		k := indices[ip[i]]
+		if k < 0 {
+			k += ln
+		}
+		if k < 0 || k >= ln {
+			a.err = IndexError
+			if debug {
+				a.debug = fmt.Sprintf("Index received by %s() does not exist shape: %v axis: %d index: %d", mthd, a.shape, axis, indices[ip[i]])
+				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return nil, false
+		}
+		pos[i] += k * step
+	}
+	return pos, true
+}
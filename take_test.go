@@ -0,0 +1,151 @@
+package numgo
+
+import (
+	"math"
+	"testing"
+)
+
+func init() {
+	debug = true
+}
+
+func TestTake(t *testing.T) {
+	a := Arange(12).Reshape(3, 4)
+	for i, v := range []struct {
+		a    *Array64
+		idx  []int
+		axis int
+		res  *Array64
+		err  error
+	}{
+		{a, []int{2, 0}, 0, NewArray64([]float64{8, 9, 10, 11, 0, 1, 2, 3}, 2, 4), nil},
+		{a, []int{-1, 1, 1}, 1, NewArray64([]float64{3, 1, 1, 7, 5, 5, 11, 9, 9}, 3, 3), nil},
+		{a, []int{}, 1, NewArray64(nil, 3, 0), nil},
+		{Arange(5), []int{4, 0}, 0, NewArray64([]float64{4, 0}), nil},
+		{cols(a, 2), []int{2, 1}, 0, NewArray64([]float64{10, 6}), nil},
+		{a, []int{3}, 0, nil, IndexError},
+		{a, []int{0, -5}, 1, nil, IndexError},
+		{a, []int{0}, 2, nil, IndexError},
+		{a, []int{0}, -1, nil, IndexError},
+		{&Array64{err: InvIndexError}, []int{0}, 0, nil, InvIndexError},
+	} {
+		r := v.a.Take(v.idx, v.axis)
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && (!equalShape(r.shape, v.res.shape) || !r.Equals(v.res).All().At(0)) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+}
+
+func TestPut(t *testing.T) {
+	for i, v := range []struct {
+		idx  []int
+		vals *Array64
+		res  []float64
+		err  error
+	}{
+		{[]int{0, 5}, NewArray64([]float64{-1, -2}), []float64{-1, 1, 2, 3, 4, -2}, nil},
+		{[]int{-1, 1, 3}, FullArray64(9, 1), []float64{0, 9, 2, 9, 4, 9}, nil},
+		{[]int{0, 1, 2}, NewArray64([]float64{7, 8}), []float64{7, 8, 7, 3, 4, 5}, nil},
+		{[]int{}, NewArray64(nil), []float64{0, 1, 2, 3, 4, 5}, nil},
+		{[]int{6}, FullArray64(9, 1), nil, IndexError},
+		{[]int{-7}, FullArray64(9, 1), nil, IndexError},
+		{[]int{0}, NewArray64(nil), nil, ShapeError},
+		{[]int{0}, nil, nil, NilError},
+		{[]int{0}, &Array64{err: InvIndexError}, nil, InvIndexError},
+	} {
+		r := Arange(6).Reshape(2, 3).Put(v.idx, v.vals)
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !r.Equals(NewArray64(v.res, 2, 3)).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+
+	// Put through a view
+	a := Arange(12).Reshape(3, 4)
+	cols(a, 1).Put([]int{0, 2}, NewArray64([]float64{-1, -2}))
+	if a.At(0, 1) != -1 || a.At(2, 1) != -2 || a.At(1, 1) != 5 {
+		t.Log("Put on view failed", a)
+		t.Fail()
+	}
+}
+
+func TestTakeAlongAxis(t *testing.T) {
+	a := NewArray64([]float64{10, 30, 20, 60, 40, 50}, 2, 3)
+	for i, v := range []struct {
+		idx  *Array64
+		axis int
+		res  *Array64
+		err  error
+	}{
+		{NewArray64([]float64{0, 2, 1, 1, 2, 0}, 2, 3), 1, NewArray64([]float64{10, 20, 30, 40, 50, 60}, 2, 3), nil},
+		{NewArray64([]float64{1, 0}, 2, 1), 1, NewArray64([]float64{30, 60}, 2, 1), nil},
+		{NewArray64([]float64{1, 0, 1}, 1, 3), 0, NewArray64([]float64{60, 30, 50}, 1, 3), nil},
+		{NewArray64([]float64{-1}, 1, 1), 1, NewArray64([]float64{20, 50}, 2, 1), nil},
+		{NewArray64([]float64{0, 1}, 1, 2), 1, NewArray64([]float64{10, 30, 60, 40}, 2, 2), nil},
+		{NewArray64([]float64{0, 1, 0, 1, 0, 1}, 3, 2), 1, nil, ShapeError},
+		{NewArray64([]float64{0, 1}), 1, nil, ShapeError},
+		{NewArray64([]float64{3}, 1, 1), 1, nil, IndexError},
+		{NewArray64([]float64{0.5}, 1, 1), 1, nil, InvIndexError},
+		{NewArray64([]float64{math.NaN()}, 1, 1), 1, nil, InvIndexError},
+		{NewArray64([]float64{0}, 1, 1), 2, nil, IndexError},
+		{nil, 1, nil, NilError},
+		{&Array64{err: ShapeError}, 1, nil, ShapeError},
+	} {
+		r := a.C().TakeAlongAxis(v.idx, v.axis)
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && (!equalShape(r.shape, v.res.shape) || !r.Equals(v.res).All().At(0)) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+
+	// Transposed view source
+	tr := a.view(0, []int{3, 2}, []int{1, 3})
+	if r := tr.TakeAlongAxis(NewArray64([]float64{2, 0}, 1, 2), 0); !r.Equals(NewArray64([]float64{20, 60}, 1, 2)).All().At(0) {
+		t.Log("TakeAlongAxis on view failed", r)
+		t.Fail()
+	}
+}
+
+func TestPutAlongAxis(t *testing.T) {
+	for i, v := range []struct {
+		idx, vals *Array64
+		axis      int
+		res       []float64
+		err       error
+	}{
+		{NewArray64([]float64{2, 0}, 2, 1), FullArray64(-1, 1), 1, []float64{0, 1, -1, -1, 4, 5}, nil},
+		{NewArray64([]float64{1, 0, 1}, 1, 3), NewArray64([]float64{7, 8, 9}), 0, []float64{0, 8, 2, 7, 4, 9}, nil},
+		{NewArray64([]float64{0, 2}, 1, 2), NewArray64([]float64{7, 8, 9, 10}, 2, 2), 1, []float64{7, 1, 8, 9, 4, 10}, nil},
+		{NewArray64([]float64{0, 2}, 1, 2), NewArray64([]float64{7, 8, 9}), 1, nil, ShapeError},
+		{NewArray64([]float64{5}, 1, 1), FullArray64(0, 1), 1, nil, IndexError},
+		{NewArray64([]float64{0}, 1, 1), nil, 1, nil, NilError},
+		{NewArray64([]float64{0}, 1, 1), &Array64{err: InvIndexError}, 1, nil, InvIndexError},
+	} {
+		r := Arange(6).Reshape(2, 3).PutAlongAxis(v.idx, v.vals, v.axis)
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !r.Equals(NewArray64(v.res, 2, 3)).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package numgo
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TxtOptions configures reading and writing delimited text with LoadTxt, ReadCSV and SaveTxt.
+// The zero value reads all columns, with the default delimiter of each function.
+type TxtOptions struct {
+	// Delimiter separates the fields of a row.  Zero uses whitespace for LoadTxt,
+	// a comma for ReadCSV and a space for SaveTxt.
+	Delimiter rune
+	// Comment starts a comment that runs to the end of the line.  Zero disables comments.
+	// ReadCSV only supports comments at the start of a line.
+	Comment rune
+	// SkipRows is the number of leading lines skipped before reading, such as a header.
+	SkipRows int
+	// Columns selects the columns to read, in order.  Negative columns count from the last column.
+	// Nil reads all columns.
+	Columns []int
+	// Missing lists the fields that are read as NaN, in addition to empty fields.
+	Missing []string
+	// Format is the fmt verb that SaveTxt formats elements with.  Empty uses "%v".
+	Format string
+	// Header is written by SaveTxt as the first row, when it isn't empty.
+	Header []string
+}
+
+// parseTxt converts the fields of each row to a 2-D array, with the selected columns of each row.
+// All rows must have the same number of fields.
+func parseTxt(rows [][]string, opts TxtOptions, mthd string) *Array64 {
+	if len(rows) == 0 {
+		return newArray64(0, len(opts.Columns))
+	}
+
+	n, cols := len(rows[0]), opts.Columns
+	if cols == nil {
+		cols = make([]int, n)
+		for i := range cols {
+			cols[i] = i
+		}
+	}
+	idx := make([]int, len(cols))
+	for i, c := range cols {
+		if c < 0 {
+			c += n
+		}
+		if c < 0 || c >= n {
+			return ErrArray[float64](IndexError, "Column received by %s() out of range.  Columns: %v  Fields: %d", mthd, cols, n)
+		}
+		idx[i] = c
+	}
+
+	r := newArray64(len(rows), len(idx))
+	for i, row := range rows {
+		if len(row) != n {
+			return ErrArray[float64](ShapeError, "Rows received by %s() do not match.  Row %d has %d fields, expected %d.", mthd, i, len(row), n)
+		}
+		for j, c := range idx {
+			f := strings.TrimSpace(row[c])
+			if f == "" || containsString(opts.Missing, f) {
+				r.data[i*len(idx)+j] = math.NaN()
+				continue
+			}
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return ErrArray[float64](FormatError, "Value received by %s() is not a number.  Row: %d  Column: %d  Value: %q", mthd, i, c, f)
+			}
+			r.data[i*len(idx)+j] = v
+		}
+	}
+	return r
+}
+
+// containsString reports whether v is in s.
+func containsString(s []string, v string) bool {
+	for _, w := range s {
+		if w == v {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadTxt reads a 2-D array from delimited text, with one row of the array on each line.
+// Fields are separated by whitespace, or by opts.Delimiter when it's set.  Blank lines and
+// comments are skipped, and empty or missing fields are read as NaN.
+//
+// Fields that aren't numbers, and read errors, generate a FormatError.  Rows with a different
+// number of fields generate a ShapeError, and columns out of range generate an IndexError.
+func LoadTxt(r io.Reader, opts TxtOptions) *Array64 {
+	var rows [][]string
+	br := bufio.NewReader(r)
+	for ln := 0; ; ln++ {
+		line, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return ErrArray[float64](FormatError, "Data received by LoadTxt() can not be read.  Line: %d  Error: %v", ln, err)
+		}
+		if i := strings.IndexRune(line, opts.Comment); opts.Comment != 0 && i >= 0 {
+			line = line[:i]
+		}
+
+		switch {
+		case ln < opts.SkipRows, strings.TrimSpace(line) == "":
+		case opts.Delimiter == 0:
+			rows = append(rows, strings.Fields(line))
+		default:
+			rows = append(rows, strings.Split(strings.TrimRight(line, "\r\n"), string(opts.Delimiter)))
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	return parseTxt(rows, opts, "LoadTxt")
+}
+
+// ReadCSV reads a 2-D array from CSV data, with one row of the array in each record.
+// Fields are separated by commas, or by opts.Delimiter when it's set, and may be quoted.
+// SkipRows skips records instead of lines, so quoted fields in a header can span lines.
+//
+// Errors are the same as LoadTxt.
+func ReadCSV(r io.Reader, opts TxtOptions) *Array64 {
+	cr := csv.NewReader(r)
+	cr.Comment, cr.FieldsPerRecord, cr.TrimLeadingSpace = opts.Comment, -1, true
+	if opts.Delimiter != 0 {
+		cr.Comma = opts.Delimiter
+	}
+
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return ErrArray[float64](FormatError, "Data received by ReadCSV() can not be read.  Error: %v", err)
+	}
+	if opts.SkipRows > len(rows) {
+		opts.SkipRows = len(rows)
+	}
+	return parseTxt(rows[opts.SkipRows:], opts, "ReadCSV")
+}
+
+// SaveTxt writes the array to w as delimited text, with one row of the array on each line.
+// A 1-D array is written with one element on each line.
+// Elements are formatted with opts.Format, and separated by opts.Delimiter or a space.
+//
+// Arrays with more than two axes generate a ShapeError, which is returned.
+// Arrays in error return their error, and errors from w are returned unchanged.
+func (a *Array[T]) SaveTxt(w io.Writer, opts TxtOptions) error {
+	switch {
+	case a.HasErr():
+		return a.getErr()
+	case len(a.shape) > 2:
+		return ShapeError
+	}
+
+	delim, verb := " ", "%v"
+	if opts.Delimiter != 0 {
+		delim = string(opts.Delimiter)
+	}
+	if opts.Format != "" {
+		verb = opts.Format
+	}
+
+	bw := bufio.NewWriter(w)
+	if len(opts.Header) > 0 {
+		bw.WriteString(strings.Join(opts.Header, delim) + "\n")
+	}
+	d, cols := a.flat(), a.shape[len(a.shape)-1]
+	if len(a.shape) == 1 {
+		cols = 1
+	}
+	for i, v := range d {
+		fmt.Fprintf(bw, verb, v)
+		if (i+1)%cols == 0 {
+			bw.WriteByte('\n')
+			continue
+		}
+		bw.WriteString(delim)
+	}
+	return bw.Flush()
+}
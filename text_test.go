@@ -0,0 +1,138 @@
+package numgo
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestLoadTxt(t *testing.T) {
+	t.Parallel()
+	nan := math.NaN()
+	for i, v := range []struct {
+		in   string
+		opts TxtOptions
+		res  *Array64
+	}{
+		{"1 2 3\n4 5 6\n", TxtOptions{}, NewArray64([]float64{1, 2, 3, 4, 5, 6}, 2, 3)},
+		{"  1\t2 \n\n3   4", TxtOptions{}, NewArray64([]float64{1, 2, 3, 4}, 2, 2)},
+		{"a b\n# comment\n1 2 # trailing\n3 4\n", TxtOptions{SkipRows: 1, Comment: '#'}, NewArray64([]float64{1, 2, 3, 4}, 2, 2)},
+		{"1;;3\r\n4;NA;-6e1\r\n", TxtOptions{Delimiter: ';', Missing: []string{"NA"}}, NewArray64([]float64{1, nan, 3, 4, nan, -60}, 2, 3)},
+		{"1,2,3\n4,5,6\n", TxtOptions{Delimiter: ',', Columns: []int{2, 0}}, NewArray64([]float64{3, 1, 6, 4}, 2, 2)},
+		{"1,2,3\n4,5,6\n", TxtOptions{Delimiter: ',', Columns: []int{-1}}, NewArray64([]float64{3, 6}, 2, 1)},
+		{"NaN Inf -inf\n", TxtOptions{}, NewArray64([]float64{nan, math.Inf(1), math.Inf(-1)}, 1, 3)},
+		{"", TxtOptions{}, NewArray64(nil, 0, 0)},
+		{"x y\n", TxtOptions{SkipRows: 3, Columns: []int{0}}, NewArray64(nil, 0, 1)},
+	} {
+		a := LoadTxt(strings.NewReader(v.in), v.opts)
+		if e := a.GetErr(); e != nil || !sameNaN(a, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Received", a, e)
+			t.Fail()
+		}
+	}
+
+	for i, v := range []struct {
+		a   *Array64
+		err error
+	}{
+		{LoadTxt(strings.NewReader("1 2\n3\n"), TxtOptions{}), ShapeError},
+		{LoadTxt(strings.NewReader("1 x\n"), TxtOptions{}), FormatError},
+		{LoadTxt(strings.NewReader("1 2\n"), TxtOptions{Columns: []int{2}}), IndexError},
+		{LoadTxt(strings.NewReader("1 2\n"), TxtOptions{Columns: []int{-3}}), IndexError},
+		{LoadTxt(errReader{}, TxtOptions{}), FormatError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
+
+// errReader fails every read.
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read failed") }
+
+func TestReadCSV(t *testing.T) {
+	t.Parallel()
+	nan := math.NaN()
+	for i, v := range []struct {
+		in   string
+		opts TxtOptions
+		res  *Array64
+	}{
+		{"a,b\n1,2\n3,4\n", TxtOptions{SkipRows: 1}, NewArray64([]float64{1, 2, 3, 4}, 2, 2)},
+		{"\"x\ny\",b\n1, 2\n", TxtOptions{SkipRows: 1}, NewArray64([]float64{1, 2}, 1, 2)},
+		{"1,\"2.5\",\n# skip\n4,,6\n", TxtOptions{Comment: '#'}, NewArray64([]float64{1, 2.5, nan, 4, nan, 6}, 2, 3)},
+		{"1\t-\t3\n", TxtOptions{Delimiter: '\t', Missing: []string{"-"}, Columns: []int{1, 2}}, NewArray64([]float64{nan, 3}, 1, 2)},
+		{"a,b\n", TxtOptions{SkipRows: 2}, NewArray64(nil, 0, 0)},
+	} {
+		a := ReadCSV(strings.NewReader(v.in), v.opts)
+		if e := a.GetErr(); e != nil || !sameNaN(a, v.res) {
+			t.Log("Test", i, "Expected", v.res, "Received", a, e)
+			t.Fail()
+		}
+	}
+
+	for i, v := range []struct {
+		a   *Array64
+		err error
+	}{
+		{ReadCSV(strings.NewReader("1,2\n3\n"), TxtOptions{}), ShapeError},
+		{ReadCSV(strings.NewReader("1,\"2\n"), TxtOptions{}), FormatError},
+		{ReadCSV(strings.NewReader("a,b\n1,2\n"), TxtOptions{}), FormatError},
+		{ReadCSV(strings.NewReader("1,2\n"), TxtOptions{Columns: []int{5}}), IndexError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
+
+func TestSaveTxt(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	for i, v := range []struct {
+		save func() error
+		exp  string
+	}{
+		{func() error { return Arange(6).Reshape(2, 3).SaveTxt(&b, TxtOptions{}) }, "0 1 2\n3 4 5\n"},
+		{func() error { return Arange(3).SaveTxt(&b, TxtOptions{}) }, "0\n1\n2\n"},
+		{func() error {
+			return NewArray64([]float64{0.5, math.NaN(), 3, 4}, 2, 2).SaveTxt(&b, TxtOptions{Delimiter: ',', Format: "%.2f", Header: []string{"x", "y"}})
+		}, "x,y\n0.50,NaN\n3.00,4.00\n"},
+		{func() error { return Arange(6).Reshape(2, 3).T().SaveTxt(&b, TxtOptions{Delimiter: '\t'}) }, "0\t3\n1\t4\n2\t5\n"},
+		{func() error { return NewArray([]int8{-1, 2}, 1, 2).SaveTxt(&b, TxtOptions{Format: "%03d"}) }, "-01 002\n"},
+		{func() error { return NewArrayB([]bool{true, false}, 2).SaveTxt(&b, TxtOptions{}) }, "true\nfalse\n"},
+		{func() error { return NewArray64(nil, 0, 3).SaveTxt(&b, TxtOptions{}) }, ""},
+	} {
+		b.Reset()
+		if err := v.save(); err != nil || b.String() != v.exp {
+			t.Log("Test", i, "Expected", v.exp, "Received", b.String(), err)
+			t.Fail()
+		}
+	}
+
+	// Saved text is read back unchanged.
+	a := RandArray64(-1e6, 1e6, 4, 5)
+	b.Reset()
+	if err := a.SaveTxt(&b, TxtOptions{Delimiter: ','}); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	if r := ReadCSV(&b, TxtOptions{}); !r.Equals(a).All().At(0) {
+		t.Log("Round trip failed.  Expected", a, "Received", r)
+		t.Fail()
+	}
+
+	if err := Arange(8).Reshape(2, 2, 2).SaveTxt(&b, TxtOptions{}); err != ShapeError {
+		t.Log("Expected ShapeError, received", err)
+		t.Fail()
+	}
+	if err := Arange(8).Reshape(3).SaveTxt(&b, TxtOptions{}); err != ReshapeError {
+		t.Log("Expected ReshapeError, received", err)
+		t.Fail()
+	}
+}
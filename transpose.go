@@ -0,0 +1,178 @@
+package numgo
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// blockSize is the edge length of the tiles used by transpose2, chosen so a tile
+// of the source and destination fit in L1 cache together.
+const blockSize = 32
+
+// Transpose returns a view of the array with the axes permuted.
+// Axis i of the result is axis perm[i] of the source.  With no axes given,
+// the order of the axes is reversed, which is the matrix transpose for 2-D arrays.
+//
+// The view shares the data of the source, so changes made through it are visible in the source.
+// Every axis must be listed exactly once, or a ShapeError will be generated.
+func (a *Array[T]) Transpose(perm ...int) *Array[T] {
+	if a.valPerm(&perm, "Transpose") {
+		return a
+	}
+	return a.transpose(perm)
+}
+
+// T returns a view of the transpose of the array, reversing the order of the axes.
+// Equivalent to Transpose().
+func (a *Array[T]) T() *Array[T] {
+	if a.valPerm(nil, "T") {
+		return a
+	}
+	return a.transpose(revAxes(len(a.shape)))
+}
+
+// SwapAxes returns a view of the array with axes i and j interchanged.
+func (a *Array[T]) SwapAxes(i, j int) *Array[T] {
+	if a.valAxes([]int{i, j}, "SwapAxes") {
+		return a
+	}
+	return a.transpose(swapAxes(len(a.shape), i, j))
+}
+
+// MoveAxis returns a view of the array with axis src moved to position dst.
+// The order of the remaining axes is unchanged.
+func (a *Array[T]) MoveAxis(src, dst int) *Array[T] {
+	if a.valAxes([]int{src, dst}, "MoveAxis") {
+		return a
+	}
+	return a.transpose(moveAxis(len(a.shape), src, dst))
+}
+
+// transpose creates the permuted view of the array.  perm must be validated before calling.
+func (a *Array[T]) transpose(perm []int) *Array[T] {
+	sh, st := make([]int, len(perm)), make([]int, len(perm))
+	for i, v := range perm {
+		sh[i], st[i] = a.shape[v], a.strides[v+1]
+	}
+	return a.view(a.offset, sh, st)
+}
+
+// valPerm validates that perm holds each axis of the array exactly once.
+// An empty perm is replaced by the reversed axes.
+func (a *Array[T]) valPerm(perm *[]int, mthd string) bool {
+	if a.HasErr() {
+		return true
+	}
+	if perm == nil || len(*perm) == 0 {
+		if perm != nil {
+			*perm = revAxes(len(a.shape))
+		}
+		return false
+	}
+
+	if len(*perm) != len(a.shape) {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Incorrect number of axes received by %s().  Shape: %v  Axes: %v", mthd, a.shape, *perm)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return true
+	}
+	if a.valAxes(*perm, mthd) {
+		return true
+	}
+	if !isPerm(*perm) {
+		a.err = ShapeError
+		if debug {
+			a.debug = fmt.Sprintf("Repeated axis received by %s().  Shape: %v  Axes: %v", mthd, a.shape, *perm)
+			a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+		}
+		return true
+	}
+	return false
+}
+
+// valAxes checks that each axis exists in the array.
+func (a *Array[T]) valAxes(axes []int, mthd string) bool {
+	if a.HasErr() {
+		return true
+	}
+	for _, v := range axes {
+		if v < 0 || v >= len(a.shape) {
+			a.err = IndexError
+			if debug {
+				a.debug = fmt.Sprintf("Axis out of range received by %s().  Shape: %v  Axes: %v", mthd, a.shape, axes)
+				a.stack = string(stackBuf[:runtime.Stack(stackBuf, false)])
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// isPerm checks that perm holds each value from 0 to len(perm)-1 exactly once.
+// Values must be in range before calling.
+func isPerm(perm []int) bool {
+	seen := make([]bool, len(perm))
+	for _, v := range perm {
+		if seen[v] {
+			return false
+		}
+		seen[v] = true
+	}
+	return true
+}
+
+// revAxes creates the permutation that reverses n axes.
+func revAxes(n int) []int {
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = n - 1 - i
+	}
+	return perm
+}
+
+// swapAxes creates the permutation of n axes that interchanges axes i and j.
+func swapAxes(n, i, j int) []int {
+	perm := make([]int, n)
+	for k := range perm {
+		perm[k] = k
+	}
+	perm[i], perm[j] = j, i
+	return perm
+}
+
+// moveAxis creates the permutation of n axes that moves axis src to position dst.
+func moveAxis(n, src, dst int) []int {
+	perm := make([]int, 0, n)
+	for k := 0; k < n; k++ {
+		if k != src {
+			perm = append(perm, k)
+		}
+	}
+	perm = append(perm[:dst], append([]int{src}, perm[dst:]...)...)
+	return perm
+}
+
+// isTrans2 checks that element strides st describe the transpose of a contiguous
+// row-major matrix, which gather copies with transpose2.
+func isTrans2(shape, st []int) bool {
+	return len(shape) == 2 && shape[0] > 1 && shape[1] > 1 && st[0] == 1 && st[1] == shape[0]
+}
+
+// transpose2 writes the transpose of the n x m row-major matrix src into dst.
+// The matrix is processed in square tiles, so reads and writes stay in cache for large matrices.
+func transpose2[T any](dst, src []T, n, m int) {
+	for ib := 0; ib < n; ib += blockSize {
+		ie := min(ib+blockSize, n)
+		for jb := 0; jb < m; jb += blockSize {
+			je := min(jb+blockSize, m)
+			for i := ib; i < ie; i++ {
+				row := src[i*m : i*m+m]
+				for j := jb; j < je; j++ {
+					dst[j*n+i] = row[j]
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package numgo
+
+import (
+	"math"
+	"testing"
+)
+
+func init() {
+	debug = true
+}
+
+// permRef builds the permuted copy of a element by element.
+func permRef(a *Array64, perm []int) *Array64 {
+	sh := make([]int, len(perm))
+	for i, v := range perm {
+		sh[i] = a.shape[v]
+	}
+	r := newArray64(sh...)
+	idx, src := make([]int, len(sh)), make([]int, len(sh))
+	for n := range r.data {
+		for i, v := range perm {
+			src[v] = idx[i]
+		}
+		r.data[n] = a.At(src...)
+		for i := len(idx) - 1; i >= 0; i-- {
+			if idx[i]++; idx[i] < sh[i] {
+				break
+			}
+			idx[i] = 0
+		}
+	}
+	return r
+}
+
+func TestTranspose(t *testing.T) {
+	a := Arange(24).Reshape(2, 3, 4)
+	for i, v := range []struct {
+		a    *Array64
+		perm []int
+		res  *Array64
+		err  error
+	}{
+		{Arange(6).Reshape(2, 3), nil, NewArray64([]float64{0, 3, 1, 4, 2, 5}, 3, 2), nil},
+		{Arange(6).Reshape(2, 3), []int{0, 1}, Arange(6).Reshape(2, 3), nil},
+		{Arange(5), nil, Arange(5), nil},
+		{a, nil, permRef(a, []int{2, 1, 0}), nil},
+		{a, []int{1, 0, 2}, permRef(a, []int{1, 0, 2}), nil},
+		{a, []int{2, 0, 1}, permRef(a, []int{2, 0, 1}), nil},
+		{a, []int{0, 2, 1}, permRef(a, []int{0, 2, 1}), nil},
+		{cols(Arange(20).Reshape(4, 5), 1).Reshape(2, 2), nil, NewArray64([]float64{1, 11, 6, 16}, 2, 2), nil},
+		{Arange(100*70).Reshape(100, 70), nil, permRef(Arange(100*70).Reshape(100, 70), []int{1, 0}), nil},
+		{a.C(), []int{0, 1}, nil, ShapeError},
+		{a.C(), []int{0, 1, 1}, nil, ShapeError},
+		{a.C(), []int{0, 1, 3}, nil, IndexError},
+		{a.C(), []int{0, -1, 1}, nil, IndexError},
+		{&Array64{err: InvIndexError}, nil, nil, InvIndexError},
+		{nil, nil, nil, NilError},
+	} {
+		r := v.a.Transpose(v.perm...)
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !r.Equals(v.res).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+
+	// Transposes are views, so writes through them are visible in the source.
+	a = Arange(24).Reshape(2, 3, 4)
+	b := a.T()
+	b.Set(-1, 3, 2, 1)
+	if a.At(1, 2, 3) != -1 || b.At(3, 2, 1) != -1 {
+		t.Log("Write through T not visible in source", a)
+		t.Fail()
+	}
+	m := Arange(6).Reshape(2, 3)
+	mt := m.T()
+	mt.AddC(10)
+	if !m.Equals(NewArray64([]float64{10, 11, 12, 13, 14, 15}, 2, 3)).All().At(0) {
+		t.Log("AddC through T not visible in source", m)
+		t.Fail()
+	}
+	if c := mt.C(); !c.contig() || !c.Equals(NewArray64([]float64{10, 13, 11, 14, 12, 15}, 3, 2)).All().At(0) {
+		t.Log("C of transposed view Expected [[10 13] [11 14] [12 15]] Got", c)
+		t.Fail()
+	}
+}
+
+func TestSwapMoveAxes(t *testing.T) {
+	a := Arange(120).Reshape(2, 3, 4, 5)
+	for i, v := range []struct {
+		swap       bool
+		x, y       int
+		perm, shap []int
+		err        error
+	}{
+		{true, 0, 3, []int{3, 1, 2, 0}, []int{5, 3, 4, 2}, nil},
+		{true, 2, 2, []int{0, 1, 2, 3}, []int{2, 3, 4, 5}, nil},
+		{true, 2, 3, []int{0, 1, 3, 2}, []int{2, 3, 5, 4}, nil},
+		{false, 0, 3, []int{1, 2, 3, 0}, []int{3, 4, 5, 2}, nil},
+		{false, 3, 0, []int{3, 0, 1, 2}, []int{5, 2, 3, 4}, nil},
+		{false, 1, 2, []int{0, 2, 1, 3}, []int{2, 4, 3, 5}, nil},
+		{true, 0, 4, nil, nil, IndexError},
+		{false, -1, 0, nil, nil, IndexError},
+	} {
+		var r *Array64
+		if v.swap {
+			r = a.C().SwapAxes(v.x, v.y)
+		} else {
+			r = a.C().MoveAxis(v.x, v.y)
+		}
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err != nil {
+			continue
+		}
+		if !equalShape(r.shape, v.shap) || !r.Equals(permRef(a, v.perm)).All().At(0) {
+			t.Log("Test", i, "Expected", v.shap, "Got", r.shape)
+			t.Fail()
+		}
+	}
+}
+
+func TestTransposeb(t *testing.T) {
+	a := Arange(24).Reshape(2, 3, 4)
+	bl := func(a *Array64) *Arrayb { return a.Equals(a.C().DivC(3).Map(math.Floor).MultC(3)) }
+	for i, v := range []struct {
+		f   func(b *Arrayb) *Arrayb
+		res *Arrayb
+		err error
+	}{
+		{func(b *Arrayb) *Arrayb { return b.T() }, bl(permRef(a, []int{2, 1, 0})), nil},
+		{func(b *Arrayb) *Arrayb { return b.Transpose(1, 2, 0) }, bl(permRef(a, []int{1, 2, 0})), nil},
+		{func(b *Arrayb) *Arrayb { return b.SwapAxes(0, 2) }, bl(permRef(a, []int{2, 1, 0})), nil},
+		{func(b *Arrayb) *Arrayb { return b.MoveAxis(2, 0) }, bl(permRef(a, []int{2, 0, 1})), nil},
+		{func(b *Arrayb) *Arrayb { return b.Reshape(6, 4).T() }, bl(permRef(a.C().Reshape(6, 4), []int{1, 0})), nil},
+		{func(b *Arrayb) *Arrayb { return b.Transpose(0, 0, 1) }, nil, ShapeError},
+		{func(b *Arrayb) *Arrayb { return b.Transpose(0, 1) }, nil, ShapeError},
+		{func(b *Arrayb) *Arrayb { return b.SwapAxes(0, 3) }, nil, IndexError},
+		{func(b *Arrayb) *Arrayb { return b.MoveAxis(-1, 0) }, nil, IndexError},
+	} {
+		r := v.f(bl(a))
+		if e := r.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected error", v.err, "Got", e)
+			t.Fail()
+			continue
+		}
+		if v.err == nil && !r.Equals(v.res).All().At(0) {
+			t.Log("Test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+
+	var n *Arrayb
+	if n.T().GetErr() != NilError {
+		t.Log("Nil array not caught")
+		t.Fail()
+	}
+}
+
+func TestTranspose2(t *testing.T) {
+	for _, v := range [][2]int{{1, 1}, {1, 50}, {50, 1}, {31, 33}, {64, 64}, {65, 97}} {
+		n, m := v[0], v[1]
+		src, dst := make([]int, n*m), make([]int, n*m)
+		for i := range src {
+			src[i] = i
+		}
+		transpose2(dst, src, n, m)
+		for i := 0; i < n; i++ {
+			for j := 0; j < m; j++ {
+				if dst[j*n+i] != src[i*m+j] {
+					t.Log("transpose2", n, m, "failed at", i, j)
+					t.Fail()
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkTranspose(b *testing.B) {
+	a := Arange(1024*1024).Reshape(1024, 1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.T()
+	}
+}
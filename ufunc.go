@@ -0,0 +1,113 @@
+package numgo
+
+// Element-wise math functions.
+// These modify the source array, so they can be chained the same way as the arithmetic methods.
+//
+// Transcendental functions need a floating point or complex array, and generate a TypeError
+// on integer arrays.  Convert with AsFloat64 or AsType to use them on integer data.
+
+// ufunc applies an element-wise kernel in place, generating a TypeError if the kernel is missing.
+func (a *Array[T]) ufunc(f func(d []T), mthd string) *Array[T] {
+	if a.HasErr() || a.valOp(f != nil, mthd) {
+		return a
+	}
+
+	a.apply(f)
+	return a
+}
+
+// Exp calculates e**x for each element of the array.
+func (a *Array[T]) Exp() *Array[T] {
+	return a.ufunc(kern[T]().exp, "Exp")
+}
+
+// Log calculates the natural logarithm of each element of the array.
+func (a *Array[T]) Log() *Array[T] {
+	return a.ufunc(kern[T]().log, "Log")
+}
+
+// Log1p calculates log(1 + x) for each element, which is accurate for values near zero.
+// Complex arrays are not supported.
+func (a *Array[T]) Log1p() *Array[T] {
+	return a.ufunc(kern[T]().log1p, "Log1p")
+}
+
+// Expm1 calculates e**x - 1 for each element, which is accurate for values near zero.
+// Complex arrays are not supported.
+func (a *Array[T]) Expm1() *Array[T] {
+	return a.ufunc(kern[T]().expm1, "Expm1")
+}
+
+// Sqrt calculates the square root of each element of the array.
+// Negative real values result in NaN.
+func (a *Array[T]) Sqrt() *Array[T] {
+	return a.ufunc(kern[T]().sqrt, "Sqrt")
+}
+
+// Abs calculates the absolute value of each element of the array.
+// Complex arrays store the magnitude in the real part.
+func (a *Array[T]) Abs() *Array[T] {
+	return a.ufunc(kern[T]().abs, "Abs")
+}
+
+// Sin calculates the sine of each element of the array, in radians.
+func (a *Array[T]) Sin() *Array[T] {
+	return a.ufunc(kern[T]().sin, "Sin")
+}
+
+// Cos calculates the cosine of each element of the array, in radians.
+func (a *Array[T]) Cos() *Array[T] {
+	return a.ufunc(kern[T]().cos, "Cos")
+}
+
+// Tan calculates the tangent of each element of the array, in radians.
+func (a *Array[T]) Tan() *Array[T] {
+	return a.ufunc(kern[T]().tan, "Tan")
+}
+
+// Tanh calculates the hyperbolic tangent of each element of the array.
+func (a *Array[T]) Tanh() *Array[T] {
+	return a.ufunc(kern[T]().tanh, "Tanh")
+}
+
+// Sigmoid calculates the logistic function 1 / (1 + e**-x) of each element of the array.
+func (a *Array[T]) Sigmoid() *Array[T] {
+	return a.ufunc(kern[T]().sigmoid, "Sigmoid")
+}
+
+// Floor rounds each element of the array down to an integer value.
+// Complex arrays are not supported.
+func (a *Array[T]) Floor() *Array[T] {
+	return a.ufunc(kern[T]().floor, "Floor")
+}
+
+// Ceil rounds each element of the array up to an integer value.
+// Complex arrays are not supported.
+func (a *Array[T]) Ceil() *Array[T] {
+	return a.ufunc(kern[T]().ceil, "Ceil")
+}
+
+// Round rounds each element of the array to the nearest integer value, with halves rounded to even.
+// Complex arrays are not supported.
+func (a *Array[T]) Round() *Array[T] {
+	return a.ufunc(kern[T]().round, "Round")
+}
+
+// Sign replaces each element of the array with -1, 0 or 1, matching its sign.
+// NaN values are unchanged, and complex values are divided by their magnitude.
+func (a *Array[T]) Sign() *Array[T] {
+	return a.ufunc(kern[T]().sign, "Sign")
+}
+
+// Clip limits the elements of the array to the range [lo, hi].
+// NaN values are unchanged, and hi takes priority when lo > hi.
+// Complex values are ordered by real, then imaginary part.
+func (a *Array[T]) Clip(lo, hi T) *Array[T] {
+	k := kern[T]()
+	if a.HasErr() || a.valOp(k.clip != nil, "Clip") {
+		return a
+	}
+
+	a.apply(func(d []T) { k.clip(lo, hi, d) })
+	return a
+}
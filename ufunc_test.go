@@ -0,0 +1,161 @@
+package numgo
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestUfunc(t *testing.T) {
+	t.Parallel()
+	sigmoid := func(x float64) float64 { return 1 / (1 + math.Exp(-x)) }
+	sign := func(x float64) float64 {
+		switch {
+		case x < 0:
+			return -1
+		case x > 0:
+			return 1
+		}
+		return x
+	}
+	for i, v := range []struct {
+		f   func(*Array64) *Array64
+		exp func(float64) float64
+	}{
+		{(*Array64).Exp, math.Exp},
+		{(*Array64).Log, math.Log},
+		{(*Array64).Log1p, math.Log1p},
+		{(*Array64).Expm1, math.Expm1},
+		{(*Array64).Sqrt, math.Sqrt},
+		{(*Array64).Abs, math.Abs},
+		{(*Array64).Sin, math.Sin},
+		{(*Array64).Cos, math.Cos},
+		{(*Array64).Tan, math.Tan},
+		{(*Array64).Tanh, math.Tanh},
+		{(*Array64).Sigmoid, sigmoid},
+		{(*Array64).Floor, math.Floor},
+		{(*Array64).Ceil, math.Ceil},
+		{(*Array64).Round, math.RoundToEven},
+		{(*Array64).Sign, sign},
+	} {
+		// Odd lengths run the tails of the vectorized kernels.
+		for _, n := range []int{0, 1, 3, 4, 7, 33} {
+			d := RandArray64(-10, 20, n).Values()
+			if n > 4 {
+				d[0], d[1], d[2], d[3], d[4] = math.NaN(), math.Inf(1), math.Inf(-1), 2.5, -0.5
+			}
+			a := v.f(NewArray64(d, n))
+			if e := a.GetErr(); e != nil {
+				t.Log("Test", i, "Unexpected error", e)
+				t.Fail()
+				continue
+			}
+			for j, x := range d {
+				if r, e := a.At(j), v.exp(x); r != e && !(r != r && e != e) && math.Abs(r-e) > 1e-15*math.Abs(e) {
+					t.Log("Test", i, "Length", n, "Expected", e, "for", x, "Received", r)
+					t.Fail()
+				}
+			}
+		}
+	}
+
+	if a := Arange(1, 6).Reshape(2, 3).T().Sqrt(); !a.Equals(NewArray64([]float64{1, math.Sqrt2, math.Sqrt(3), 2, math.Sqrt(5), math.Sqrt(6)}, 2, 3).T()).All().At(0) {
+		t.Log("Transposed view failed", a)
+		t.Fail()
+	}
+	if x := NewArray64([]float64{-800, 800}, 2).Sigmoid(); x.At(0) != 0 || x.At(1) != 1 {
+		t.Log("Sigmoid overflow", x)
+		t.Fail()
+	}
+}
+
+func TestUfuncTypes(t *testing.T) {
+	t.Parallel()
+	if a := NewArray[int32]([]int32{-3, 0, 5}, 3).Abs(); a.At(0) != 3 || a.At(1) != 0 || a.At(2) != 5 {
+		t.Log("Int Abs failed", a)
+		t.Fail()
+	}
+	if a := NewArray[int8]([]int8{-3, 0, 5}, 3).Sign().Round(); a.At(0) != -1 || a.At(1) != 0 || a.At(2) != 1 {
+		t.Log("Int Sign failed", a)
+		t.Fail()
+	}
+	if a := NewArray[uint8]([]uint8{0, 5}, 2).Sign().Abs(); a.At(0) != 0 || a.At(1) != 1 {
+		t.Log("Uint Sign failed", a)
+		t.Fail()
+	}
+	if a := NewArray[float32]([]float32{4, 2.5}, 2).Sqrt().Floor(); a.At(0) != 2 || a.At(1) != 1 {
+		t.Log("Float32 failed", a)
+		t.Fail()
+	}
+
+	c := NewArray[complex128]([]complex128{3 + 4i, 1i * math.Pi, 0}, 3)
+	if a := c.C().Abs(); a.At(0) != 5 || a.At(1) != complex(math.Pi, 0) || a.At(2) != 0 {
+		t.Log("Complex Abs failed", a)
+		t.Fail()
+	}
+	if a := c.C().Exp(); cmplx.Abs(a.At(1)+1) > 1e-15 || a.At(2) != 1 {
+		t.Log("Complex Exp failed", a)
+		t.Fail()
+	}
+	if a := c.C().Sign(); a.At(0) != complex(0.6, 0.8) || a.At(2) != 0 {
+		t.Log("Complex Sign failed", a)
+		t.Fail()
+	}
+	if a := NewArray[complex128]([]complex128{-4}, 1).Sqrt(); a.At(0) != 2i {
+		t.Log("Complex Sqrt failed", a)
+		t.Fail()
+	}
+
+	for i, v := range []struct {
+		a   interface{ GetErr() error }
+		err error
+	}{
+		{NewArray[int64]([]int64{1}, 1).Exp(), TypeError},
+		{NewArray[uint16]([]uint16{1}, 1).Sqrt(), TypeError},
+		{NewArray[complex64]([]complex64{1}, 1).Floor(), TypeError},
+		{NewArray[complex128]([]complex128{1}, 1).Log1p(), TypeError},
+		{NewArrayB([]bool{true}, 1).Abs(), TypeError},
+		{NewArrayB([]bool{true}, 1).Clip(false, true), TypeError},
+		{Arange(4).Reshape(3).Tanh(), ReshapeError},
+		{(*Array64)(nil).Sin(), NilError},
+	} {
+		if e := v.a.GetErr(); e != v.err {
+			t.Log("Test", i, "Expected", v.err, "Received", e)
+			t.Fail()
+		}
+	}
+}
+
+func TestClip(t *testing.T) {
+	t.Parallel()
+	nan := math.NaN()
+	for i, v := range []struct {
+		a, exp *Array64
+		lo, hi float64
+	}{
+		{Arange(10), NewArray64([]float64{2, 2, 2, 3, 4, 5, 6, 7, 7, 7}), 2, 7},
+		{Arange(3), NewArray64([]float64{0, 1, 2}), -1, 5},
+		{NewArray64([]float64{nan, -5, 5, nan, 0}), NewArray64([]float64{nan, -1, 1, nan, 0}), -1, 1},
+		{NewArray64([]float64{math.Inf(-1), math.Inf(1), 1, 2, 3}), NewArray64([]float64{0, 2, 1, 2, 2}), 0, 2},
+		{Arange(5), full(1.0, 5), 3, 1},
+		{Arange(6).Reshape(2, 3).T(), NewArray64([]float64{1, 3, 1, 4, 2, 4}, 3, 2), 1, 4},
+	} {
+		c := v.a.Clip(v.lo, v.hi)
+		for j, x := range c.Values() {
+			if e := v.exp.Values()[j]; x != e && !(x != x && e != e) {
+				t.Log("Test", i, "Expected", v.exp, "Received", c)
+				t.Fail()
+				break
+			}
+		}
+	}
+
+	if a := NewArray[int16]([]int16{-300, 5, 300}, 3).Clip(-100, 100); a.At(0) != -100 || a.At(1) != 5 || a.At(2) != 100 {
+		t.Log("Int Clip failed", a)
+		t.Fail()
+	}
+	if a := NewArray[complex64]([]complex64{1 + 5i, 2, 3}, 3).Clip(1+6i, 2+1i); a.At(0) != 1+6i || a.At(1) != 2 || a.At(2) != 2+1i {
+		t.Log("Complex Clip failed", a)
+		t.Fail()
+	}
+}
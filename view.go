@@ -0,0 +1,205 @@
+package numgo
+
+// Arrays can be views of the data held by another array.  A view shares the
+// underlying data slice, so changes made through a view are visible in the
+// source array and vice versa.
+//
+// The element [0, 0, ..., 0] of an array is at data[offset], and the element
+// stride of axis i is held in strides[i+1].  strides[0] holds the number of
+// elements in the array.  Arrays that aren't laid out in contiguous row-major
+// order are gathered into a contiguous slice for the vectorized kernels.
+
+// contig reports whether the array elements are stored contiguously in row-major order
+// and fill the data slice, so the data can be used directly.
+func (a *Array[T]) contig() bool {
+	if a.offset != 0 || len(a.data) != a.strides[0] {
+		return false
+	}
+	return isRowMajor(a.shape, a.strides[1:])
+}
+
+// isRowMajor checks that element strides st describe a contiguous row-major layout of shape.
+func isRowMajor(shape, st []int) bool {
+	t := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		if shape[i] != 1 && st[i] != t {
+			return false
+		}
+		t *= shape[i]
+	}
+	return true
+}
+
+// rowStrides calculates the element strides of a contiguous row-major array of the given shape.
+func rowStrides(shape []int) []int {
+	st := make([]int, len(shape))
+	for i, t := len(shape)-1, 1; i >= 0; i-- {
+		st[i] = t
+		t *= shape[i]
+	}
+	return st
+}
+
+// flat returns the elements of the array as a contiguous slice.
+// Non-contiguous arrays are gathered into a new slice, so the result must be treated as read-only.
+func (a *Array[T]) flat() []T {
+	if a.contig() {
+		return a.data
+	}
+	d := make([]T, a.strides[0])
+	gather(d, a.data, a.offset, a.shape, a.strides[1:])
+	return d
+}
+
+// valCopy returns a private contiguous copy of the elements, broadcast up to shape sh.
+// Setters read their values through valCopy, because the values can be a view of the array
+// being written, and writing the array would change the values before they're all read.
+func (a *Array[T]) valCopy(sh []int) []T {
+	d := make([]T, size(sh))
+	gather(d, a.data, a.offset, sh, broadcastStrides(sh, a.shape, a.strides[1:]))
+	return d
+}
+
+// apply runs f over the contiguous elements of the array, in place.
+// Non-contiguous arrays are gathered before calling f and scattered back afterwards.
+func (a *Array[T]) apply(f func(d []T)) {
+	if a.contig() {
+		f(a.data)
+		return
+	}
+	d := a.flat()
+	f(d)
+	scatter(a.data, d, a.offset, a.shape, a.strides[1:])
+}
+
+// own replaces the data of a view with a contiguous copy, detaching it from the shared data.
+// Methods that restructure the data slice must call own before modifying it.
+func (a *Array[T]) own() {
+	if !a.shared && a.contig() {
+		return
+	}
+
+	d := make([]T, a.strides[0])
+	gather(d, a.data, a.offset, a.shape, a.strides[1:])
+	a.data, a.offset, a.shared = d, 0, false
+	copy(a.strides[1:], rowStrides(a.shape))
+}
+
+// view creates an array that shares the data of a, starting at data[off]
+// with the given shape and element strides.  Validation must be complete before calling view.
+func (a *Array[T]) view(off int, shape, st []int) *Array[T] {
+	v := &Array[T]{
+		shape:   shape,
+		strides: append([]int{size(shape)}, st...),
+		data:    a.data,
+		offset:  off,
+		shared:  true,
+		err:     nil,
+		debug:   "",
+		stack:   "",
+	}
+	a.shared = true
+
+	// Contiguous views are narrowed to their own section of data,
+	// so the fast paths can use the data slice directly.
+	if isRowMajor(shape, st) {
+		sz := v.strides[0]
+		if sz == 0 {
+			off = 0
+		}
+		v.data, v.offset = a.data[off:off+sz:off+sz], 0
+		copy(v.strides[1:], rowStrides(shape))
+	}
+	return v
+}
+
+// gather copies the elements of a strided layout into contiguous dst, in row-major order.
+// off is the position of the first element in src and st holds the element stride of each axis.
+func gather[T any](dst, src []T, off int, shape, st []int) {
+	if len(dst) == 0 {
+		return
+	}
+	if len(shape) == 0 {
+		dst[0] = src[off]
+		return
+	}
+	if isTrans2(shape, st) {
+		transpose2(dst, src[off:off+len(dst)], shape[1], shape[0])
+		return
+	}
+
+	ln, inner := shape[len(shape)-1], st[len(st)-1]
+	idx := make([]int, len(shape)-1)
+	for d := 0; d < len(dst); d += ln {
+		if inner == 1 {
+			copy(dst[d:d+ln], src[off:off+ln])
+		} else {
+			for i, o := 0, off; i < ln; i, o = i+1, o+inner {
+				dst[d+i] = src[o]
+			}
+		}
+
+		for i := len(idx) - 1; i >= 0; i-- {
+			idx[i]++
+			off += st[i]
+			if idx[i] < shape[i] {
+				break
+			}
+			off -= st[i] * idx[i]
+			idx[i] = 0
+		}
+	}
+}
+
+// scatter copies contiguous src into the strided layout of dst, in row-major order.
+// off is the position of the first element in dst and st holds the element stride of each axis.
+func scatter[T any](dst, src []T, off int, shape, st []int) {
+	if len(src) == 0 {
+		return
+	}
+	if len(shape) == 0 {
+		dst[off] = src[0]
+		return
+	}
+
+	ln, inner := shape[len(shape)-1], st[len(st)-1]
+	idx := make([]int, len(shape)-1)
+	for d := 0; d < len(src); d += ln {
+		if inner == 1 {
+			copy(dst[off:off+ln], src[d:d+ln])
+		} else {
+			for i, o := 0, off; i < ln; i, o = i+1, o+inner {
+				dst[o] = src[d+i]
+			}
+		}
+
+		for i := len(idx) - 1; i >= 0; i-- {
+			idx[i]++
+			off += st[i]
+			if idx[i] < shape[i] {
+				break
+			}
+			off -= st[i] * idx[i]
+			idx[i] = 0
+		}
+	}
+}
+
+// offsets calculates the data position of every element in a strided layout, in row-major order.
+// off is the position of the first element and st holds the element stride of each axis.
+func offsets(off int, shape, st []int) []int {
+	pos, idx := make([]int, size(shape)), make([]int, len(shape))
+	for n := range pos {
+		pos[n] = off
+		for i := len(idx) - 1; i >= 0; i-- {
+			idx[i]++
+			off += st[i]
+			if idx[i] < shape[i] {
+				break
+			}
+			off -= st[i] * idx[i]
+			idx[i] = 0
+		}
+	}
+	return pos
+}
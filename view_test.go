@@ -0,0 +1,239 @@
+package numgo
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func init() {
+	debug = true
+}
+
+// cols creates a view of column j in a 2-D array.
+func cols(a *Array64, j int) *Array64 {
+	return a.view(a.offset+j*a.strides[2], []int{a.shape[0]}, []int{a.strides[1]})
+}
+
+func TestSubArrView(t *testing.T) {
+	a := Arange(24).Reshape(2, 3, 4)
+	b := a.SubArr(1)
+	if !b.contig() || !b.shared || !a.shared {
+		t.Log("SubArr view not created", b.contig(), b.shared, a.shared)
+		t.Fail()
+	}
+
+	b.AddC(100)
+	if a.At(1, 0, 0) != 112 || a.At(0, 2, 3) != 11 {
+		t.Log("View change not seen in source", a)
+		t.Fail()
+	}
+	a.Set(-1, 1, 2, 3)
+	if b.At(2, 3) != -1 {
+		t.Log("Source change not seen in view", b)
+		t.Fail()
+	}
+
+	c := b.SubArr(1)
+	c.Set(-2, 0)
+	if a.At(1, 1, 0) != -2 {
+		t.Log("Nested view change not seen in source", a)
+		t.Fail()
+	}
+
+	// Restructuring a view detaches it from the source.
+	b.Sum(1)
+	if a.At(1, 1, 0) != -2 || a.At(1, 0, 1) != 113 {
+		t.Log("Sum of view changed the source", a)
+		t.Fail()
+	}
+	d := a.SubArr(0)
+	d.Resize(20).AddC(1)
+	if a.At(0, 0, 0) != 0 {
+		t.Log("Resize of view changed the source", a)
+		t.Fail()
+	}
+
+	e := a.C()
+	e.Set(5, 0, 0, 0)
+	if a.At(0, 0, 0) != 0 || e.shared {
+		t.Log("Copy shares data with the source")
+		t.Fail()
+	}
+}
+
+func TestStridedView(t *testing.T) {
+	a := Arange(20).Reshape(4, 5)
+	b := cols(a, 1)
+	if b.contig() {
+		t.Log("Column view reported as contiguous")
+		t.Fail()
+	}
+
+	for i, v := range []float64{1, 6, 11, 16} {
+		if b.At(i) != v {
+			t.Log("At", i, "Expected", v, "Got", b.At(i))
+			t.Fail()
+		}
+	}
+	if !b.Equals(NewArray64([]float64{1, 6, 11, 16})).All().At(0) {
+		t.Log("Equals on view failed", b)
+		t.Fail()
+	}
+	if b.String() != "[1 6 11 16]" {
+		t.Log("String on view failed", b.String())
+		t.Fail()
+	}
+	if c := b.C(); !c.contig() || c.At(3) != 16 {
+		t.Log("C did not materialize the view", c)
+		t.Fail()
+	}
+
+	b.AddC(100).MultC(2)
+	b.Add(Arange(4))
+	for i := 0; i < 4; i++ {
+		if e := float64(2*(100+i*5+1) + i); a.At(i, 1) != e {
+			t.Log("Arithmetic on view", i, "Expected", e, "Got", a.At(i, 1))
+			t.Fail()
+		}
+		if a.At(i, 0) != float64(i*5) || a.At(i, 2) != float64(i*5+2) {
+			t.Log("Arithmetic on view changed other columns", a)
+			t.Fail()
+		}
+	}
+
+	// Reversed view with negative strides
+	r := Arange(5)
+	rv := r.view(4, []int{5}, []int{-1})
+	if !rv.Equals(NewArray64([]float64{4, 3, 2, 1, 0})).All().At(0) {
+		t.Log("Reversed view failed", rv)
+		t.Fail()
+	}
+	rv.Subtr(Arange(5))
+	if !r.Equals(NewArray64([]float64{-4, -2, 0, 2, 4})).All().At(0) {
+		t.Log("Reversed view arithmetic failed", r)
+		t.Fail()
+	}
+
+	// Transposed view of a 2-D array
+	m := Arange(6).Reshape(2, 3)
+	tr := m.view(0, []int{3, 2}, []int{1, 3})
+	if !tr.Equals(NewArray64([]float64{0, 3, 1, 4, 2, 5}, 3, 2)).All().At(0) {
+		t.Log("Transposed view failed", tr)
+		t.Fail()
+	}
+	if s := tr.Sum(0); !s.Equals(NewArray64([]float64{3, 12})).All().At(0) {
+		t.Log("Sum of transposed view failed", s)
+		t.Fail()
+	}
+	if m.At(1, 2) != 5 {
+		t.Log("Sum of transposed view changed the source", m)
+		t.Fail()
+	}
+
+	tr = m.view(0, []int{3, 2}, []int{1, 3})
+	if f := tr.Fold(func(d []float64) float64 { return d[0] }, 1); !f.Equals(NewArray64([]float64{0, 1, 2})).All().At(0) {
+		t.Log("Fold of transposed view failed", f)
+		t.Fail()
+	}
+	if f := tr.Map(math.Sqrt); !f.Equals(NewArray64([]float64{0, math.Sqrt(3), 1, 2, math.Sqrt(2), math.Sqrt(5)}, 3, 2)).All().At(0) {
+		t.Log("Map of transposed view failed", f)
+		t.Fail()
+	}
+	if p := tr.MatProd(m); !p.Equals(matProd(tr.C(), m)).All().At(0) {
+		t.Log("MatProd of transposed view failed", p)
+		t.Fail()
+	}
+	if p := tr.SliceElement(1); p[0] != 1 || p[1] != 4 {
+		t.Log("SliceElement of transposed view failed", p)
+		t.Fail()
+	}
+	tr.SetSliceElement([]float64{10, 40}, 1)
+	if m.At(0, 1) != 10 || m.At(1, 1) != 40 {
+		t.Log("SetSliceElement of transposed view failed", m)
+		t.Fail()
+	}
+	tr.SetSubArr(NewArray64([]float64{-1, -2}), 2)
+	if m.At(0, 2) != -1 || m.At(1, 2) != -2 {
+		t.Log("SetSubArr of transposed view failed", m)
+		t.Fail()
+	}
+
+	tr.Reshape(6)
+	if !tr.Equals(NewArray64([]float64{0, 3, 10, 40, -1, -2})).All().At(0) || tr.shared {
+		t.Log("Reshape of transposed view failed", tr)
+		t.Fail()
+	}
+
+	j, err := json.Marshal(cols(Arange(6).Reshape(3, 2), 1))
+	if err != nil || string(j) != `{"shape":[3],"data":[1,3,5]}` {
+		t.Log("JSON of view failed", string(j), err)
+		t.Fail()
+	}
+}
+
+func TestViewHelpers(t *testing.T) {
+	for i, v := range []struct {
+		shape, st []int
+		res       bool
+	}{
+		{[]int{2, 3}, []int{3, 1}, true},
+		{[]int{2, 3}, []int{1, 2}, false},
+		{[]int{2, 1, 3}, []int{3, 100, 1}, true},
+		{[]int{1, 3}, []int{0, 1}, true},
+		{[]int{3}, []int{2}, false},
+		{[]int{}, []int{}, true},
+	} {
+		if r := isRowMajor(v.shape, v.st); r != v.res {
+			t.Log("isRowMajor test", i, "Expected", v.res, "Got", r)
+			t.Fail()
+		}
+	}
+
+	src := Arange(24).data
+	d := make([]float64, 6)
+	gather(d, src, 1, []int{2, 3}, []int{12, 4})
+	for i, v := range []float64{1, 5, 9, 13, 17, 21} {
+		if d[i] != v {
+			t.Log("gather Expected", v, "Got", d[i])
+			t.Fail()
+		}
+	}
+	dst := make([]float64, 24)
+	scatter(dst, d, 1, []int{2, 3}, []int{12, 4})
+	for i, v := range dst {
+		e := float64(0)
+		if i%4 == 1 {
+			e = float64(i)
+		}
+		if v != e {
+			t.Log("scatter at", i, "Got", v)
+			t.Fail()
+		}
+	}
+}
+
+func TestValCopy(t *testing.T) {
+	if d := Arange(3).valCopy([]int{2, 3}); len(d) != 6 || d[3] != 0 || d[5] != 2 {
+		t.Log("valCopy broadcast Expected [0 1 2 0 1 2] Got", d)
+		t.Fail()
+	}
+
+	// Setters read values from a view of the array being written through a copy.
+	exp := NewArray64([]float64{0, 0, 1, 2})
+	a := Arange(4)
+	if a.Put([]int{1, 2, 3}, a.Slice(R(0, 3))); !a.Equals(exp).All().At(0) {
+		t.Log("Put from own view Expected", exp, "Got", a)
+		t.Fail()
+	}
+	a = Arange(4)
+	if a.SetSlice(a.Slice(R(0, 3)), R(1, 4)); !a.Equals(exp).All().At(0) {
+		t.Log("SetSlice from own view Expected", exp, "Got", a)
+		t.Fail()
+	}
+	a = Arange(4)
+	if a.SetMaskArr(NewArrayB([]bool{false, true, true, true}, 4), a.Slice(R(0, 3))); !a.Equals(exp).All().At(0) {
+		t.Log("SetMaskArr from own view Expected", exp, "Got", a)
+		t.Fail()
+	}
+}